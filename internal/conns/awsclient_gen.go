@@ -311,6 +311,7 @@ import (
 
 type AWSClient struct {
 	AccountID                 string
+	DefaultIdentifierPrefix   string
 	DefaultTagsConfig         *tftags.DefaultConfig
 	DNSSuffix                 string
 	IgnoreTagsConfig          *tftags.IgnoreConfig
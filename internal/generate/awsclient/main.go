@@ -137,6 +137,7 @@ import (
 
 type AWSClient struct {
 	AccountID                 string
+	DefaultIdentifierPrefix   string
 	DefaultTagsConfig         *tftags.DefaultConfig
 	DNSSuffix                 string
 	IgnoreTagsConfig          *tftags.IgnoreConfig
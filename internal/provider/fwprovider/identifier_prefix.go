@@ -0,0 +1,46 @@
+package fwprovider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-provider-aws/internal/verify"
+)
+
+// identifierPrefixValidator validates that a string attribute is a valid identifier prefix.
+// An empty string is valid, as it leaves the prefix unset.
+type identifierPrefixValidator struct{}
+
+var (
+	_ tfsdk.AttributeValidator = identifierPrefixValidator{}
+)
+
+func (v identifierPrefixValidator) Description(_ context.Context) string {
+	return fmt.Sprintf("Lowercase alphanumeric characters and hyphens, starting with a letter, without two consecutive hyphens and at most %d characters long.", verify.IdentifierPrefixMaxLength)
+}
+
+func (v identifierPrefixValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// Validate implements attribute validation.
+func (v identifierPrefixValidator) Validate(ctx context.Context, request tfsdk.ValidateAttributeRequest, response *tfsdk.ValidateAttributeResponse) {
+	var prefix types.String
+
+	diags := tfsdk.ValueAs(ctx, request.AttributeConfig, &prefix)
+	response.Diagnostics.Append(diags...)
+
+	if diags.HasError() || prefix.Unknown || prefix.Null || prefix.Value == "" {
+		return
+	}
+
+	if err := verify.ValidateIdentifierPrefix(prefix.Value); err != nil {
+		response.Diagnostics.AddAttributeError(
+			request.AttributePath,
+			"Invalid Identifier Prefix",
+			err.Error(),
+		)
+	}
+}
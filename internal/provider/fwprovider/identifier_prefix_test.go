@@ -0,0 +1,81 @@
+package fwprovider_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-provider-aws/internal/provider/fwprovider"
+)
+
+func TestDefaultIdentifierPrefixValidate(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		val         types.String
+		expectError bool
+	}
+	tests := map[string]testCase{
+		"unknown string": {
+			val: types.String{Unknown: true},
+		},
+		"null string": {
+			val: types.String{Null: true},
+		},
+		"empty string": {
+			val: types.String{Value: ""},
+		},
+		"valid string": {
+			val: types.String{Value: "team-a-"},
+		},
+		"uppercase": {
+			val:         types.String{Value: "Team-a-"},
+			expectError: true,
+		},
+		"leading digit": {
+			val:         types.String{Value: "1team-"},
+			expectError: true,
+		},
+		"consecutive hyphens": {
+			val:         types.String{Value: "team--a"},
+			expectError: true,
+		},
+		"too long": {
+			val:         types.String{Value: "a-prefix-that-is-far-too-long-for-an-identifier"},
+			expectError: true,
+		},
+	}
+
+	for name, test := range tests {
+		name, test := name, test
+		t.Run(name, func(t *testing.T) {
+			ctx := context.TODO()
+
+			schema, diags := fwprovider.New(nil).GetSchema(ctx)
+
+			if diags.HasError() {
+				t.Fatalf("got unexpected error: %#v", diags)
+			}
+
+			request := tfsdk.ValidateAttributeRequest{
+				AttributePath:   tftypes.NewAttributePath().WithAttributeName("default_identifier_prefix"),
+				AttributeConfig: test.val,
+			}
+			response := &tfsdk.ValidateAttributeResponse{}
+
+			for _, validator := range schema.Attributes["default_identifier_prefix"].Validators {
+				validator.Validate(ctx, request, response)
+			}
+
+			if !response.Diagnostics.HasError() && test.expectError {
+				t.Fatal("expected error, got no error")
+			}
+
+			if response.Diagnostics.HasError() && !test.expectError {
+				t.Fatalf("got unexpected error: %#v", response.Diagnostics)
+			}
+		})
+	}
+}
@@ -41,6 +41,14 @@ func (p *provider) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostic
 				Optional:    true,
 				Description: "File containing custom root and intermediate certificates. Can also be configured using the `AWS_CA_BUNDLE` environment variable. (Setting `ca_bundle` in the shared config file is not supported.)",
 			},
+			"default_identifier_prefix": {
+				Type:        types.StringType,
+				Optional:    true,
+				Description: "Prefix used when generating unique identifiers for resources that have neither an identifier nor an identifier prefix configured.",
+				Validators: []tfsdk.AttributeValidator{
+					identifierPrefixValidator{},
+				},
+			},
 			"ec2_metadata_service_endpoint": {
 				Type:        types.StringType,
 				Optional:    true,
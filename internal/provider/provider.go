@@ -220,6 +220,13 @@ func Provider() *schema.Provider {
 					"Can also be configured using the `AWS_CA_BUNDLE` environment variable. " +
 					"(Setting `ca_bundle` in the shared config file is not supported.)",
 			},
+			"default_identifier_prefix": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "Prefix used when generating unique identifiers for resources " +
+					"that have neither an identifier nor an identifier prefix configured.",
+				ValidateFunc: validDefaultIdentifierPrefix,
+			},
 			"default_tags": {
 				Type:        schema.TypeList,
 				Optional:    true,
@@ -2108,6 +2115,7 @@ func Provider() *schema.Provider {
 func providerConfigure(ctx context.Context, d *schema.ResourceData, terraformVersion string) (interface{}, diag.Diagnostics) {
 	config := conns.Config{
 		AccessKey:                      d.Get("access_key").(string),
+		DefaultIdentifierPrefix:        d.Get("default_identifier_prefix").(string),
 		DefaultTagsConfig:              expandProviderDefaultTags(d.Get("default_tags").([]interface{})),
 		CustomCABundle:                 d.Get("custom_ca_bundle").(string),
 		EC2MetadataServiceEndpoint:     d.Get("ec2_metadata_service_endpoint").(string),
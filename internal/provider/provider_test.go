@@ -5,6 +5,8 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/hashicorp/terraform-provider-aws/internal/verify"
 	"github.com/hashicorp/terraform-provider-aws/names"
 )
 
@@ -170,6 +172,40 @@ func TestEndpointEnvVarPrecedence(t *testing.T) {
 	}
 }
 
+func TestProviderDefaultIdentifierPrefix(t *testing.T) {
+	testcases := []struct {
+		prefix string
+		valid  bool
+	}{
+		{prefix: "", valid: true},
+		{prefix: "tf-", valid: true},
+		{prefix: "team-a-", valid: true},
+		{prefix: strings.Repeat("a", verify.IdentifierPrefixMaxLength), valid: true},
+		{prefix: strings.Repeat("a", verify.IdentifierPrefixMaxLength+1), valid: false},
+		{prefix: "Team-a-", valid: false},
+		{prefix: "team_a-", valid: false},
+		{prefix: "1team-", valid: false},
+		{prefix: "-team", valid: false},
+		{prefix: "team--a", valid: false},
+	}
+
+	p := Provider()
+
+	for _, testcase := range testcases {
+		diags := p.Validate(terraform.NewResourceConfigRaw(map[string]interface{}{
+			"default_identifier_prefix": testcase.prefix,
+		}))
+
+		if testcase.valid && diags.HasError() {
+			t.Errorf("Unexpected error for default_identifier_prefix %q: %v", testcase.prefix, diags)
+		}
+
+		if !testcase.valid && !diags.HasError() {
+			t.Errorf("Expected an error for default_identifier_prefix %q", testcase.prefix)
+		}
+	}
+}
+
 func stashEnv() []string {
 	env := os.Environ()
 	os.Clearenv()
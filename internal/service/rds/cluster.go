@@ -1370,6 +1370,8 @@ func resourceClusterUpdate(d *schema.ResourceData, meta interface{}) error {
 		}
 	}
 
+	clusterInstanceDBClusters.Invalidate(conn, d.Id())
+
 	return resourceClusterRead(d, meta)
 }
 
@@ -1439,6 +1441,8 @@ func resourceClusterDelete(d *schema.ResourceData, meta interface{}) error {
 		return fmt.Errorf("error waiting for RDS Cluster (%s) deletion: %s", d.Id(), err)
 	}
 
+	clusterInstanceDBClusters.Invalidate(conn, d.Id())
+
 	return nil
 }
 
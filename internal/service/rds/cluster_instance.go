@@ -1,18 +1,32 @@
 package rds
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log"
+	"net"
+	"regexp"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/rds"
+	"github.com/aws/aws-sdk-go/service/rds/rdsiface"
 	"github.com/hashicorp/aws-sdk-go-base/v2/awsv1shim/v2/tfawserr"
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/hashicorp/terraform-provider-aws/internal/conns"
+	"github.com/hashicorp/terraform-provider-aws/internal/create"
+	"github.com/hashicorp/terraform-provider-aws/internal/flex"
+	tfec2 "github.com/hashicorp/terraform-provider-aws/internal/service/ec2"
 	tftags "github.com/hashicorp/terraform-provider-aws/internal/tags"
 	"github.com/hashicorp/terraform-provider-aws/internal/tfresource"
 	"github.com/hashicorp/terraform-provider-aws/internal/verify"
@@ -20,16 +34,16 @@ import (
 
 func ResourceClusterInstance() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceClusterInstanceCreate,
-		Read:   resourceClusterInstanceRead,
-		Update: resourceClusterInstanceUpdate,
-		Delete: resourceClusterInstanceDelete,
+		Create: clusterInstanceOperation(ClusterInstanceOperationCreate, resourceClusterInstanceCreate),
+		Read:   clusterInstanceOperation(ClusterInstanceOperationRead, resourceClusterInstanceRead),
+		Update: clusterInstanceOperation(ClusterInstanceOperationUpdate, resourceClusterInstanceUpdate),
+		Delete: clusterInstanceOperation(ClusterInstanceOperationDelete, resourceClusterInstanceDelete),
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: resourceClusterInstanceImport,
 		},
 
 		Timeouts: &schema.ResourceTimeout{
-			Create: schema.DefaultTimeout(90 * time.Minute),
+			Create: schema.DefaultTimeout(clusterInstanceCreateTimeout),
 			Update: schema.DefaultTimeout(90 * time.Minute),
 			Delete: schema.DefaultTimeout(90 * time.Minute),
 		},
@@ -40,6 +54,14 @@ func ResourceClusterInstance() *schema.Resource {
 				Computed: true,
 			},
 
+			"backup_target": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice(BackupTarget_Values(), false),
+			},
+
 			"identifier": {
 				Type:          schema.TypeString,
 				Optional:      true,
@@ -63,11 +85,37 @@ func ResourceClusterInstance() *schema.Resource {
 				Computed: true,
 			},
 
+			"db_subnet_group_arn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"subnet_ids": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
 			"writer": {
 				Type:     schema.TypeBool,
 				Computed: true,
 			},
 
+			"writer_endpoint": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"write_forwarding_enabled": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+
+			"needs_reboot": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+
 			"cluster_identifier": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -81,6 +129,17 @@ func ResourceClusterInstance() *schema.Resource {
 
 			"port": {
 				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
+
+			"hosted_zone_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"http_endpoint_enabled": {
+				Type:     schema.TypeBool,
 				Computed: true,
 			},
 
@@ -90,23 +149,43 @@ func ResourceClusterInstance() *schema.Resource {
 				Default:  false,
 			},
 
+			"allow_public_access": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+
 			"instance_class": {
 				Type:     schema.TypeString,
 				Required: true,
 			},
 
+			"instance_class_family": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
 			"engine": {
 				Type:         schema.TypeString,
 				Optional:     true,
 				ForceNew:     true,
 				Default:      "aurora",
-				ValidateFunc: validEngine(),
+				ValidateFunc: validClusterInstanceEngine,
+			},
+
+			"deprecated_engine_error": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
 			},
 
 			"engine_version": {
 				Type:     schema.TypeString,
 				Optional: true,
 				Computed: true,
+				// "latest" is only resolved on create, the running version is recorded in engine_version_actual.
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return new == engineVersionLatest && old != ""
+				},
 			},
 
 			"engine_version_actual": {
@@ -114,6 +193,21 @@ func ResourceClusterInstance() *schema.Resource {
 				Computed: true,
 			},
 
+			"engine_version_matches_cluster": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+
+			"engine_version_upgrade_available": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+
+			"engine_version_major": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
 			"db_parameter_group_name": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -129,6 +223,46 @@ func ResourceClusterInstance() *schema.Resource {
 				Computed: true,
 			},
 
+			"apply_maintenance_action": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice(OptInType_Values(), false),
+			},
+
+			"apply_immediately_overrides": {
+				Type:         schema.TypeMap,
+				Optional:     true,
+				Elem:         &schema.Schema{Type: schema.TypeBool},
+				ValidateFunc: validateClusterInstanceApplyImmediatelyOverrides,
+			},
+
+			"change_freeze": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"end": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.IsRFC3339Time,
+						},
+						"start": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.IsRFC3339Time,
+						},
+					},
+				},
+			},
+
+			"license_model": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
 			"kms_key_id": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -139,6 +273,11 @@ func ResourceClusterInstance() *schema.Resource {
 				Computed: true,
 			},
 
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
 			"dbi_resource_id": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -156,6 +295,12 @@ func ResourceClusterInstance() *schema.Resource {
 				Computed: true,
 			},
 
+			"monitoring_role_ready": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
 			"preferred_maintenance_window": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -189,6 +334,16 @@ func ResourceClusterInstance() *schema.Resource {
 				Default:  0,
 			},
 
+			"failover_priority": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"reboot_trigger": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
 			"availability_zone": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -196,6 +351,40 @@ func ResourceClusterInstance() *schema.Resource {
 				Computed: true,
 			},
 
+			"availability_zone_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"lookup_availability_zone_id": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"lookup_ca_cert_expiring_soon": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"lookup_engine_version_upgrade_available": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"lookup_pending_maintenance_actions": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"multi_az": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+
 			"performance_insights_enabled": {
 				Type:     schema.TypeBool,
 				Optional: true,
@@ -209,6 +398,11 @@ func ResourceClusterInstance() *schema.Resource {
 				ValidateFunc: verify.ValidARN,
 			},
 
+			"performance_insights_resource_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
 			"performance_insights_retention_period": {
 				Type:     schema.TypeInt,
 				Optional: true,
@@ -228,128 +422,490 @@ func ResourceClusterInstance() *schema.Resource {
 				Optional: true,
 				Default:  false,
 			},
+
+			"retroactively_tag_snapshots": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
 			"ca_cert_identifier": {
 				Type:     schema.TypeString,
 				Optional: true,
 				Computed: true,
 			},
 
-			"tags":     tftags.TagsSchema(),
-			"tags_all": tftags.TagsSchemaComputed(),
-		},
+			"ca_cert_expiring_soon": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
 
-		CustomizeDiff: verify.SetTagsDiff,
-	}
-}
+			"ca_cert_key_type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 
-func resourceClusterInstanceCreate(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*conns.AWSClient).RDSConn
-	defaultTagsConfig := meta.(*conns.AWSClient).DefaultTagsConfig
-	tags := defaultTagsConfig.MergeTags(tftags.New(d.Get("tags").(map[string]interface{})))
+			"skip_delete_wait": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
 
-	createOpts := &rds.CreateDBInstanceInput{
-		DBInstanceClass:         aws.String(d.Get("instance_class").(string)),
-		CopyTagsToSnapshot:      aws.Bool(d.Get("copy_tags_to_snapshot").(bool)),
-		DBClusterIdentifier:     aws.String(d.Get("cluster_identifier").(string)),
-		Engine:                  aws.String(d.Get("engine").(string)),
-		PubliclyAccessible:      aws.Bool(d.Get("publicly_accessible").(bool)),
-		PromotionTier:           aws.Int64(int64(d.Get("promotion_tier").(int))),
-		AutoMinorVersionUpgrade: aws.Bool(d.Get("auto_minor_version_upgrade").(bool)),
-		Tags:                    Tags(tags.IgnoreAWS()),
-	}
+			"wait_for_connectivity": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
 
-	if attr, ok := d.GetOk("availability_zone"); ok {
-		createOpts.AvailabilityZone = aws.String(attr.(string))
-	}
+			"validate_before_create": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
 
-	if attr, ok := d.GetOk("db_parameter_group_name"); ok {
-		createOpts.DBParameterGroupName = aws.String(attr.(string))
-	}
+			"validate_orderable_instance_class": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
 
-	if v, ok := d.GetOk("identifier"); ok {
-		createOpts.DBInstanceIdentifier = aws.String(v.(string))
-	} else {
-		if v, ok := d.GetOk("identifier_prefix"); ok {
-			createOpts.DBInstanceIdentifier = aws.String(resource.PrefixedUniqueId(v.(string)))
-		} else {
-			createOpts.DBInstanceIdentifier = aws.String(resource.PrefixedUniqueId("tf-"))
-		}
-	}
+			"enabled_cloudwatch_logs_exports": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
 
-	if attr, ok := d.GetOk("db_subnet_group_name"); ok {
-		createOpts.DBSubnetGroupName = aws.String(attr.(string))
-	}
+			"vpc_security_group_ids": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
 
-	if attr, ok := d.GetOk("engine_version"); ok {
-		createOpts.EngineVersion = aws.String(attr.(string))
-	}
+			"backup_retention_period": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
 
-	if attr, ok := d.GetOk("monitoring_role_arn"); ok {
-		createOpts.MonitoringRoleArn = aws.String(attr.(string))
-	}
+			"backup_window": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 
-	if attr, ok := d.GetOk("performance_insights_enabled"); ok {
-		createOpts.EnablePerformanceInsights = aws.Bool(attr.(bool))
-	}
+			"backup_window_overlaps_maintenance": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
 
-	if attr, ok := d.GetOk("performance_insights_kms_key_id"); ok {
-		createOpts.PerformanceInsightsKMSKeyId = aws.String(attr.(string))
-	}
+			"cluster_deletion_protection": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
 
-	if attr, ok := d.GetOk("performance_insights_retention_period"); ok {
-		createOpts.PerformanceInsightsRetentionPeriod = aws.Int64(int64(attr.(int)))
-	}
+			"cluster_endpoint": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 
-	if attr, ok := d.GetOk("preferred_backup_window"); ok {
-		createOpts.PreferredBackupWindow = aws.String(attr.(string))
-	}
+			"cluster_reader_endpoint": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 
-	if attr, ok := d.GetOk("preferred_maintenance_window"); ok {
-		createOpts.PreferredMaintenanceWindow = aws.String(attr.(string))
-	}
+			"cluster_iam_roles": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"feature_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"role_arn": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
 
-	if attr, ok := d.GetOk("monitoring_interval"); ok {
-		createOpts.MonitoringInterval = aws.Int64(int64(attr.(int)))
-	}
+			"customer_owned_ip_enabled": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
 
-	log.Printf("[DEBUG] Creating RDS DB Instance opts: %s", createOpts)
-	var resp *rds.CreateDBInstanceOutput
-	err := resource.Retry(propagationTimeout, func() *resource.RetryError {
-		var err error
-		resp, err = conn.CreateDBInstance(createOpts)
-		if err != nil {
-			if tfawserr.ErrMessageContains(err, "InvalidParameterValue", "IAM role ARN value is invalid or does not include the required permissions") {
-				return resource.RetryableError(err)
-			}
-			return resource.NonRetryableError(err)
-		}
-		return nil
-	})
-	if tfresource.TimedOut(err) {
-		resp, err = conn.CreateDBInstance(createOpts)
-	}
-	if err != nil {
-		return fmt.Errorf("error creating RDS Cluster (%s) Instance: %w", d.Get("cluster_identifier").(string), err)
-	}
+			"outpost_arns": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
 
-	d.SetId(aws.StringValue(resp.DBInstance.DBInstanceIdentifier))
+			"read_replica_db_instance_identifiers": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"pending_maintenance_actions": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"action": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"auto_applied_after_date": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"current_apply_date": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"forced_apply_date": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"opt_in_status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+
+			"status_infos": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"message": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"normal": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"status_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+
+			"auto_failover_before_delete": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"prevent_last_instance_delete_with_deletion_protection": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"prevent_writer_delete_with_readers": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"proxy_target_delete_check": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice(ProxyTargetDeleteCheck_Values(), false),
+			},
+
+			"skip_final_snapshot": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
+			"skip_final_snapshot_on_quota_exceeded": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"final_snapshot_identifier": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ValidateFunc: validation.All(
+					validation.StringMatch(regexp.MustCompile(`^[A-Za-z]`), "must begin with alphabetic character"),
+					validation.StringMatch(regexp.MustCompile(`^[0-9A-Za-z-]+$`), "must only contain alphanumeric characters and hyphens"),
+					validation.StringDoesNotMatch(regexp.MustCompile(`--`), "cannot contain two consecutive hyphens"),
+					validation.StringDoesNotMatch(regexp.MustCompile(`-$`), "cannot end in a hyphen"),
+				),
+			},
+
+			"tags":     tftags.TagsSchema(),
+			"tags_all": tftags.TagsSchemaComputed(),
+		},
+
+		CustomizeDiff: customdiff.Sequence(
+			resourceClusterInstanceCustomizeDiffEngine,
+			resourceClusterInstanceCustomizeDiffBackupTarget,
+			resourceClusterInstanceCustomizeDiffParameterGroupFamily,
+			resourceClusterInstanceCustomizeDiffPort,
+			resourceClusterInstanceCustomizeDiffCACertIdentifier,
+			resourceClusterInstanceCustomizeDiffAvailabilityZone,
+			resourceClusterInstanceCustomizeDiffServerlessInstanceClass,
+			resourceClusterInstanceCustomizeDiffOrderableInstanceClass,
+			resourceClusterInstanceCustomizeDiffLicenseModel,
+			resourceClusterInstanceCustomizeDiffPubliclyAccessible,
+			resourceClusterInstanceCustomizeDiffMonitoringInterval,
+			resourceClusterInstanceCustomizeDiffFinalSnapshot,
+			resourceClusterInstanceCustomizeDiffPerformanceInsightsKMSKeyID,
+			verify.SetTagsDiff,
+			resourceClusterInstanceCustomizeDiffTags,
+		),
+	}
+}
+
+// clusterInstanceOperation wraps the errors returned by the specified operation's function in a ClusterInstanceError.
+func clusterInstanceOperation(operation string, f func(*schema.ResourceData, interface{}) error) func(*schema.ResourceData, interface{}) error {
+	return func(d *schema.ResourceData, meta interface{}) error {
+		err := f(d, meta)
+
+		if err == nil {
+			return nil
+		}
+
+		// The ID isn't set until the DB instance has been created.
+		id := d.Id()
+		if id == "" {
+			id = d.Get("identifier").(string)
+		}
+
+		return NewClusterInstanceError(operation, id, err)
+	}
+}
+
+func resourceClusterInstanceImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	// Neither auto_failover_before_delete, deprecated_engine_error, lookup_availability_zone_id, lookup_ca_cert_expiring_soon,
+	// lookup_engine_version_upgrade_available, lookup_pending_maintenance_actions, monitoring_role_ready,
+	// prevent_last_instance_delete_with_deletion_protection, prevent_writer_delete_with_readers, retroactively_tag_snapshots,
+	// skip_delete_wait, skip_final_snapshot, skip_final_snapshot_on_quota_exceeded, validate_before_create,
+	// validate_orderable_instance_class, wait_for_connectivity nor final_snapshot_identifier can be fetched from any API call,
+	// so set their defaults.
+	d.Set("auto_failover_before_delete", false)
+	d.Set("deprecated_engine_error", false)
+	d.Set("lookup_availability_zone_id", false)
+	d.Set("lookup_ca_cert_expiring_soon", false)
+	d.Set("lookup_engine_version_upgrade_available", false)
+	d.Set("lookup_pending_maintenance_actions", false)
+	d.Set("monitoring_role_ready", false)
+	d.Set("prevent_last_instance_delete_with_deletion_protection", false)
+	d.Set("prevent_writer_delete_with_readers", false)
+	d.Set("retroactively_tag_snapshots", false)
+	d.Set("skip_delete_wait", false)
+	d.Set("skip_final_snapshot", true)
+	d.Set("skip_final_snapshot_on_quota_exceeded", false)
+	d.Set("validate_before_create", false)
+	d.Set("validate_orderable_instance_class", false)
+	d.Set("wait_for_connectivity", false)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceClusterInstanceCreate(d *schema.ResourceData, meta interface{}) error {
+	start := time.Now()
+
+	if err := validateClusterInstanceChangeFreeze(d.Get("change_freeze").([]interface{}), start); err != nil {
+		return fmt.Errorf("error creating RDS Cluster Instance: %w", err)
+	}
+
+	conn := meta.(*conns.AWSClient).RDSConn
+
+	// A missing cluster is left to CreateDBInstance to report.
+	if clusterID := d.Get("cluster_identifier").(string); clusterID != "" {
+		dbCluster, err := FindDBClusterByID(conn, clusterID)
+
+		if err != nil && !tfresource.NotFound(err) {
+			return fmt.Errorf("error reading RDS Cluster (%s): %w", clusterID, err)
+		}
+
+		if dbCluster != nil {
+			if err := validateClusterInstanceClusterEngineMode(dbCluster); err != nil {
+				return fmt.Errorf("error creating RDS Cluster Instance: %w", err)
+			}
+		}
+	}
+
+	defaultTagsConfig := meta.(*conns.AWSClient).DefaultTagsConfig
+	tags := defaultTagsConfig.MergeTags(tftags.New(d.Get("tags").(map[string]interface{})))
+
+	createOpts := &rds.CreateDBInstanceInput{
+		DBInstanceClass:         aws.String(d.Get("instance_class").(string)),
+		CopyTagsToSnapshot:      aws.Bool(d.Get("copy_tags_to_snapshot").(bool)),
+		DBClusterIdentifier:     aws.String(d.Get("cluster_identifier").(string)),
+		Engine:                  aws.String(d.Get("engine").(string)),
+		PubliclyAccessible:      aws.Bool(d.Get("publicly_accessible").(bool)),
+		PromotionTier:           aws.Int64(int64(d.Get("promotion_tier").(int))),
+		AutoMinorVersionUpgrade: aws.Bool(d.Get("auto_minor_version_upgrade").(bool)),
+		Tags:                    Tags(tags.IgnoreAWS()),
+	}
+
+	if attr, ok := d.GetOk("availability_zone"); ok {
+		createOpts.AvailabilityZone = aws.String(attr.(string))
+	}
+
+	if attr, ok := d.GetOk("backup_target"); ok {
+		createOpts.BackupTarget = aws.String(attr.(string))
+	}
+
+	// Aurora engines only support a single license model, which is set by RDS.
+	if attr, ok := d.GetOk("license_model"); ok && !isAuroraEngine(d.Get("engine").(string)) {
+		createOpts.LicenseModel = aws.String(attr.(string))
+	}
+
+	if attr, ok := d.GetOk("db_parameter_group_name"); ok {
+		createOpts.DBParameterGroupName = aws.String(attr.(string))
+	}
+
+	if v, ok := d.GetOk("identifier"); ok {
+		createOpts.DBInstanceIdentifier = aws.String(v.(string))
+	} else {
+		if v, ok := d.GetOk("identifier_prefix"); ok {
+			createOpts.DBInstanceIdentifier = aws.String(resource.PrefixedUniqueId(v.(string)))
+		} else {
+			prefix := "tf-"
+			if v := meta.(*conns.AWSClient).DefaultIdentifierPrefix; v != "" {
+				prefix = v
+			}
+			createOpts.DBInstanceIdentifier = aws.String(resource.PrefixedUniqueId(prefix))
+		}
+	}
+
+	if attr, ok := d.GetOk("db_subnet_group_name"); ok {
+		createOpts.DBSubnetGroupName = aws.String(attr.(string))
+	}
+
+	if attr, ok := d.GetOk("engine_version"); ok {
+		engineVersion := attr.(string)
+
+		if engineVersion == engineVersionLatest {
+			engine := d.Get("engine").(string)
+
+			v, err := findLatestDBEngineVersion(conn, engine)
+
+			if err != nil {
+				return fmt.Errorf("error reading latest RDS engine version (%s): %w", engine, err)
+			}
+
+			engineVersion = v
+		}
+
+		createOpts.EngineVersion = aws.String(engineVersion)
+	}
+
+	if attr, ok := d.GetOk("monitoring_role_arn"); ok {
+		createOpts.MonitoringRoleArn = aws.String(attr.(string))
+	}
+
+	if attr, ok := d.GetOk("performance_insights_enabled"); ok {
+		createOpts.EnablePerformanceInsights = aws.Bool(attr.(bool))
+	}
+
+	if attr, ok := d.GetOk("performance_insights_kms_key_id"); ok {
+		createOpts.PerformanceInsightsKMSKeyId = aws.String(attr.(string))
+	}
+
+	if attr, ok := d.GetOk("performance_insights_retention_period"); ok {
+		createOpts.PerformanceInsightsRetentionPeriod = aws.Int64(int64(attr.(int)))
+	}
+
+	if attr, ok := d.GetOk("preferred_backup_window"); ok {
+		createOpts.PreferredBackupWindow = aws.String(attr.(string))
+	}
+
+	if attr, ok := d.GetOk("preferred_maintenance_window"); ok {
+		createOpts.PreferredMaintenanceWindow = aws.String(attr.(string))
+	}
+
+	if attr, ok := d.GetOk("monitoring_interval"); ok {
+		createOpts.MonitoringInterval = aws.Int64(int64(attr.(int)))
+	}
+
+	if d.Get("validate_before_create").(bool) {
+		if err := DryRunCreateClusterInstance(conn, createOpts); err != nil {
+			return fmt.Errorf("error validating RDS Cluster (%s) Instance: %w", d.Get("cluster_identifier").(string), err)
+		}
+	}
+
+	createTimeout := d.Timeout(schema.TimeoutCreate)
+
+	// A DB instance with the configured identifier may still be being deleted, e.g. after a failed create.
+	if _, ok := d.GetOk("identifier"); ok {
+		if err := waitDBInstanceDeletingDeleted(conn, aws.StringValue(createOpts.DBInstanceIdentifier), createTimeout); err != nil {
+			return fmt.Errorf("error waiting for RDS Cluster (%s) Instance (%s) being deleted to be deleted: %w", d.Get("cluster_identifier").(string), aws.StringValue(createOpts.DBInstanceIdentifier), err)
+		}
+	}
+
+	log.Printf("[DEBUG] Creating RDS DB Instance opts: %s", createOpts)
+	// Creating many instances in parallel against the same cluster can briefly
+	// put the cluster into a "modifying" state which rejects further creates.
+	outputRaw, err := tfresource.RetryWhen(
+		createTimeout,
+		func() (interface{}, error) {
+			var resp *rds.CreateDBInstanceOutput
+			err := RetryClusterInstanceIAMPropagation(clusterInstanceMonitoringRoleIAMPropagationTimeout(d.Get("monitoring_role_ready").(bool)), func() error {
+				var err error
+				resp, err = conn.CreateDBInstance(createOpts)
+				return err
+			})
+			return resp, err
+		},
+		func(err error) (bool, error) {
+			if tfawserr.ErrMessageContains(err, rds.ErrCodeInvalidDBClusterStateFault, "modifying") ||
+				tfawserr.ErrMessageContains(err, rds.ErrCodeInvalidDBClusterStateFault, "being modified") {
+				return true, err
+			}
+
+			return false, err
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("error creating RDS Cluster (%s) Instance: %w", d.Get("cluster_identifier").(string), ClusterInstanceCreateError(err, createOpts))
+	}
+
+	resp := outputRaw.(*rds.CreateDBInstanceOutput)
+
+	d.SetId(aws.StringValue(resp.DBInstance.DBInstanceIdentifier))
 
 	// reuse db_instance refresh func
+	delay, minTimeout := clusterInstancePollDelays()
 	stateConf := &resource.StateChangeConf{
 		Pending:    resourceClusterInstanceCreateUpdatePendingStates,
 		Target:     []string{"available"},
 		Refresh:    resourceDBInstanceStateRefreshFunc(d.Id(), conn),
-		Timeout:    d.Timeout(schema.TimeoutCreate),
-		MinTimeout: 10 * time.Second,
-		Delay:      30 * time.Second,
+		Timeout:    createTimeout,
+		MinTimeout: minTimeout,
+		Delay:      delay,
 	}
 
 	// Wait, catching any errors
-	_, err = stateConf.WaitForState()
+	outputRaw, err = stateConf.WaitForState()
 	if err != nil {
 		return err
 	}
 
+	// The instance as described once available, nil once it has been modified.
+	dbInstance, _ := outputRaw.(*rds.DBInstance)
+
 	// See also: resource_aws_db_instance.go
 	// Some API calls (e.g. CreateDBInstanceReadReplica and
 	// RestoreDBInstanceFromDBSnapshot do not support all parameters to
@@ -373,6 +929,13 @@ func resourceClusterInstanceCreate(d *schema.ResourceData, meta interface{}) err
 		requiresRebootDbInstance = true
 	}
 
+	// Non-Aurora instances inherit the port of their cluster on create.
+	// Changing the port causes RDS to reboot the instance.
+	if attr, ok := d.GetOk("port"); ok && !isAuroraEngine(d.Get("engine").(string)) && (resp.DBInstance.Endpoint == nil || int64(attr.(int)) != aws.Int64Value(resp.DBInstance.Endpoint.Port)) {
+		modifyDbInstanceInput.DBPortNumber = aws.Int64(int64(attr.(int)))
+		requiresModifyDbInstance = true
+	}
+
 	if requiresModifyDbInstance {
 		modifyDbInstanceInput.DBInstanceIdentifier = aws.String(d.Id())
 
@@ -389,6 +952,9 @@ func resourceClusterInstanceCreate(d *schema.ResourceData, meta interface{}) err
 		if err != nil {
 			return fmt.Errorf("error waiting for RDS Cluster Instance (%s) to be available: %w", d.Id(), err)
 		}
+
+		// The modify may have changed the endpoint, e.g. its port.
+		dbInstance = nil
 	}
 
 	if requiresRebootDbInstance {
@@ -411,6 +977,37 @@ func resourceClusterInstanceCreate(d *schema.ResourceData, meta interface{}) err
 		}
 	}
 
+	// The endpoint may be reported some time after the instance is available.
+	// If it still isn't, the cluster's reader endpoint is used until it is.
+	if !dbInstanceHasEndpoint(dbInstance) {
+		dbInstance, err = waitDBInstanceEndpointAvailable(conn, d.Id(), propagationTimeout)
+
+		if err != nil {
+			log.Printf("[WARN] RDS Cluster Instance (%s) endpoint not available: %s", d.Id(), err)
+		}
+	}
+
+	if v, ok := d.GetOk("apply_maintenance_action"); ok {
+		if err := resourceClusterInstanceApplyPendingMaintenanceActions(conn, d.Id(), aws.StringValue(resp.DBInstance.DBInstanceArn), v.(string)); err != nil {
+			return err
+		}
+	}
+
+	// "available" doesn't guarantee that the engine is accepting connections yet.
+	if d.Get("wait_for_connectivity").(bool) {
+		if !dbInstanceHasEndpoint(dbInstance) {
+			return fmt.Errorf("error waiting for RDS Cluster Instance (%s) to accept connections: endpoint not available", d.Id())
+		}
+
+		address := net.JoinHostPort(aws.StringValue(dbInstance.Endpoint.Address), strconv.FormatInt(aws.Int64Value(dbInstance.Endpoint.Port), 10))
+
+		if err := waitClusterInstanceAcceptingConnections(net.DialTimeout, address, createTimeout-time.Since(start)); err != nil {
+			return fmt.Errorf("error waiting for RDS Cluster Instance (%s) to accept connections on %s: %w", d.Id(), address, err)
+		}
+	}
+
+	clusterInstanceDBClusters.Invalidate(conn, d.Get("cluster_identifier").(string))
+
 	return resourceClusterInstanceRead(d, meta)
 }
 
@@ -437,7 +1034,7 @@ func resourceClusterInstanceRead(d *schema.ResourceData, meta interface{}) error
 		return fmt.Errorf("DBClusterIdentifier is missing from RDS Cluster Instance (%s). The aws_db_instance resource should be used for non-Aurora instances", d.Id())
 	}
 
-	dbc, err := FindDBClusterByID(conn, dbClusterID)
+	dbc, err := clusterInstanceDBClusters.Get(conn, dbClusterID)
 
 	if err != nil {
 		return fmt.Errorf("error reading RDS Cluster (%s): %w", dbClusterID, err)
@@ -453,36 +1050,158 @@ func resourceClusterInstanceRead(d *schema.ResourceData, meta interface{}) error
 		}
 	}
 
-	if db.Endpoint != nil {
-		d.Set("endpoint", db.Endpoint.Address)
-		d.Set("port", db.Endpoint.Port)
+	endpoint := flattenClusterInstanceEndpoint(db, dbc)
+	if endpoint != nil {
+		d.Set("endpoint", endpoint.Address)
+		d.Set("hosted_zone_id", endpoint.HostedZoneId)
 	}
+	d.Set("port", flattenClusterInstancePort(d.Get("port").(int), endpoint, aws.StringValue(db.Engine)))
+	d.Set("writer_endpoint", flattenClusterInstanceWriterEndpoint(d.Id(), endpoint, dbc))
 
 	if db.DBSubnetGroup != nil {
 		d.Set("db_subnet_group_name", db.DBSubnetGroup.DBSubnetGroupName)
+		d.Set("db_subnet_group_arn", db.DBSubnetGroup.DBSubnetGroupArn)
+
+		var subnetIDs []string
+		for _, v := range db.DBSubnetGroup.Subnets {
+			if v != nil {
+				subnetIDs = append(subnetIDs, aws.StringValue(v.SubnetIdentifier))
+			}
+		}
+		if err := d.Set("subnet_ids", subnetIDs); err != nil {
+			return fmt.Errorf("error setting subnet_ids: %w", err)
+		}
 	}
 
 	d.Set("arn", db.DBInstanceArn)
 	d.Set("auto_minor_version_upgrade", db.AutoMinorVersionUpgrade)
-	d.Set("availability_zone", db.AvailabilityZone)
+	d.Set("availability_zone", flattenClusterInstanceAvailabilityZone(d.Get("availability_zone").(string), db, dbc))
+
+	// RDS only reports the Availability Zone name, which maps to a different physical Availability Zone in each account.
+	if az := d.Get("availability_zone").(string); az != "" && d.Get("lookup_availability_zone_id").(bool) {
+		input := &ec2.DescribeAvailabilityZonesInput{
+			ZoneNames: aws.StringSlice([]string{az}),
+		}
+
+		availabilityZones, err := tfec2.FindAvailabilityZones(meta.(*conns.AWSClient).EC2Conn, input)
+
+		if err != nil {
+			return fmt.Errorf("error reading EC2 Availability Zone (%s): %w", az, err)
+		}
+
+		d.Set("availability_zone_id", flattenClusterInstanceAvailabilityZoneID(az, availabilityZones))
+	} else {
+		d.Set("availability_zone_id", nil)
+	}
+
+	if d.Get("lookup_engine_version_upgrade_available").(bool) {
+		upgradeAvailable, err := ClusterInstanceEngineVersionUpgradeAvailable(conn, aws.StringValue(db.Engine), aws.StringValue(db.EngineVersion))
+
+		if err != nil {
+			return fmt.Errorf("error reading RDS Cluster Instance (%s) engine version upgrade targets: %w", d.Id(), err)
+		}
+
+		d.Set("engine_version_upgrade_available", upgradeAvailable)
+	} else {
+		d.Set("engine_version_upgrade_available", nil)
+	}
+
+	if d.Get("lookup_pending_maintenance_actions").(bool) {
+		actions, err := FindPendingMaintenanceActionsByResourceARN(conn, aws.StringValue(db.DBInstanceArn))
+
+		if err != nil {
+			return fmt.Errorf("error reading RDS Cluster Instance (%s) pending maintenance actions: %w", d.Id(), err)
+		}
+
+		if err := d.Set("pending_maintenance_actions", flattenClusterInstancePendingMaintenanceActions(actions)); err != nil {
+			return fmt.Errorf("error setting pending_maintenance_actions: %w", err)
+		}
+	} else {
+		d.Set("pending_maintenance_actions", nil)
+	}
+
+	d.Set("backup_target", db.BackupTarget)
 	d.Set("cluster_identifier", db.DBClusterIdentifier)
 	d.Set("copy_tags_to_snapshot", db.CopyTagsToSnapshot)
 	d.Set("dbi_resource_id", db.DbiResourceId)
+	d.Set("cluster_deletion_protection", dbc.DeletionProtection)
+	d.Set("cluster_endpoint", dbc.Endpoint)
+	d.Set("http_endpoint_enabled", dbc.HttpEndpointEnabled)
+	d.Set("cluster_reader_endpoint", dbc.ReaderEndpoint)
+	d.Set("enabled_cloudwatch_logs_exports", aws.StringValueSlice(dbc.EnabledCloudwatchLogsExports))
+
+	if err := d.Set("cluster_iam_roles", flattenClusterInstanceClusterIAMRoles(dbc.AssociatedRoles)); err != nil {
+		return fmt.Errorf("error setting cluster_iam_roles: %w", err)
+	}
+
+	if err := d.Set("status_infos", flattenClusterInstanceStatusInfos(db.StatusInfos)); err != nil {
+		return fmt.Errorf("error setting status_infos: %w", err)
+	}
+
 	d.Set("engine", db.Engine)
 	d.Set("identifier", db.DBInstanceIdentifier)
+	d.Set("identifier_prefix", create.NamePrefixFromName(aws.StringValue(db.DBInstanceIdentifier)))
 	d.Set("instance_class", db.DBInstanceClass)
+	d.Set("engine_version_matches_cluster", flattenClusterInstanceEngineVersionMatchesCluster(db, dbc))
+	d.Set("instance_class_family", flattenClusterInstanceInstanceClassFamily(aws.StringValue(db.DBInstanceClass)))
 	d.Set("kms_key_id", db.KmsKeyId)
+	d.Set("license_model", db.LicenseModel)
 	d.Set("monitoring_interval", db.MonitoringInterval)
+	d.Set("multi_az", db.MultiAZ)
 	d.Set("monitoring_role_arn", db.MonitoringRoleArn)
 	d.Set("performance_insights_enabled", db.PerformanceInsightsEnabled)
+	// Performance Insights identifies DB instances by their DbiResourceId.
+	d.Set("performance_insights_resource_id", db.DbiResourceId)
 	d.Set("performance_insights_kms_key_id", db.PerformanceInsightsKMSKeyId)
-	d.Set("performance_insights_retention_period", db.PerformanceInsightsRetentionPeriod)
+	d.Set("performance_insights_retention_period", flattenClusterInstancePerformanceInsightsRetentionPeriod(d.Get("performance_insights_retention_period").(int), db))
 	d.Set("preferred_backup_window", db.PreferredBackupWindow)
 	d.Set("preferred_maintenance_window", db.PreferredMaintenanceWindow)
+	d.Set("backup_retention_period", dbc.BackupRetentionPeriod)
+	d.Set("backup_window", dbc.PreferredBackupWindow)
+	d.Set("backup_window_overlaps_maintenance", flattenClusterInstanceBackupWindowOverlapsMaintenance(aws.StringValue(db.PreferredMaintenanceWindow), aws.StringValue(dbc.PreferredBackupWindow)))
 	d.Set("promotion_tier", db.PromotionTier)
+	d.Set("failover_priority", db.PromotionTier)
+	d.Set("write_forwarding_enabled", flattenClusterInstanceWriteForwardingEnabled(dbc))
+	d.Set("needs_reboot", flattenClusterInstanceNeedsReboot(db))
+	d.Set("read_replica_db_instance_identifiers", flattenClusterInstanceReadReplicaDBInstanceIdentifiers(db))
+	d.Set("customer_owned_ip_enabled", db.CustomerOwnedIpEnabled)
+	d.Set("outpost_arns", flattenClusterInstanceOutpostARNs(db))
 	d.Set("publicly_accessible", db.PubliclyAccessible)
 	d.Set("storage_encrypted", db.StorageEncrypted)
-	d.Set("ca_cert_identifier", db.CACertificateIdentifier)
+	d.Set("status", db.DBInstanceStatus)
+
+	var vpcg []string
+	for _, g := range dbc.VpcSecurityGroups {
+		vpcg = append(vpcg, aws.StringValue(g.VpcSecurityGroupId))
+	}
+	if err := d.Set("vpc_security_group_ids", vpcg); err != nil {
+		return fmt.Errorf("error setting vpc_security_group_ids: %w", err)
+	}
+
+	// When ca_cert_identifier isn't configured RDS assigns a Region's default CA certificate, which AWS rotates over time.
+	// The effective CA certificate is always recorded, the previously known one is kept if none is reported yet.
+	if v := aws.StringValue(db.CACertificateIdentifier); v != "" {
+		d.Set("ca_cert_identifier", v)
+	}
+
+	if v := d.Get("ca_cert_identifier").(string); v != "" && d.Get("lookup_ca_cert_expiring_soon").(bool) {
+		certificate, err := FindCertificateByID(conn, v)
+
+		switch {
+		// Without permission to describe the CA certificate, whether it expires soon is unknown.
+		case tfawserr.ErrCodeEquals(err, errCodeAccessDenied):
+			log.Printf("[WARN] Unable to read RDS Certificate (%s), ca_cert_expiring_soon is unknown: %s", v, err)
+			d.Set("ca_cert_expiring_soon", nil)
+		case err != nil && !tfresource.NotFound(err):
+			return fmt.Errorf("error reading RDS Certificate (%s): %w", v, err)
+		default:
+			d.Set("ca_cert_expiring_soon", flattenCertificateExpiringSoon(certificate, time.Now()))
+		}
+	} else {
+		d.Set("ca_cert_expiring_soon", nil)
+	}
+
+	d.Set("ca_cert_key_type", flattenClusterInstanceCACertKeyType(d.Get("ca_cert_identifier").(string)))
 
 	clusterSetResourceDataEngineVersionFromClusterInstance(d, db)
 
@@ -490,10 +1209,12 @@ func resourceClusterInstanceRead(d *schema.ResourceData, meta interface{}) error
 		d.Set("db_parameter_group_name", db.DBParameterGroups[0].DBParameterGroupName)
 	}
 
-	tags, err := ListTags(conn, aws.StringValue(db.DBInstanceArn))
+	tags, err := ClusterInstanceTags(conn, db)
 	if err != nil {
 		return fmt.Errorf("error listing tags for RDS Cluster Instance (%s): %w", d.Id(), err)
 	}
+	// Ignored keys are removed before default tags so that tags_all is exactly the instance's unignored tags
+	// and tags is those minus the default tags.
 	tags = tags.IgnoreAWS().IgnoreConfig(ignoreTagsConfig)
 
 	//lintignore:AWSR002
@@ -510,111 +1231,230 @@ func resourceClusterInstanceRead(d *schema.ResourceData, meta interface{}) error
 
 func resourceClusterInstanceUpdate(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*conns.AWSClient).RDSConn
-	requestUpdate := false
 
-	req := &rds.ModifyDBInstanceInput{
-		ApplyImmediately:     aws.Bool(d.Get("apply_immediately").(bool)),
-		DBInstanceIdentifier: aws.String(d.Id()),
-	}
+	// Tags don't depend on the instance's modifications, so they're updated while those are applied.
+	// The tags update doesn't use d, which isn't safe for concurrent use.
+	var updateTags func() error
+	if d.HasChange("tags_all") {
+		id, arn := d.Id(), d.Get("arn").(string)
+		o, n := d.GetChange("tags_all")
 
-	if d.HasChange("db_parameter_group_name") {
-		req.DBParameterGroupName = aws.String(d.Get("db_parameter_group_name").(string))
-		requestUpdate = true
-	}
+		updateTags = func() error {
+			if err := UpdateTags(conn, arn, o, n); err != nil {
+				return fmt.Errorf("error updating RDS Cluster Instance (%s) tags: %w", id, err)
+			}
 
-	if d.HasChange("instance_class") {
-		req.DBInstanceClass = aws.String(d.Get("instance_class").(string))
-		requestUpdate = true
+			return nil
+		}
 	}
 
-	if d.HasChange("monitoring_role_arn") {
-		req.MonitoringRoleArn = aws.String(d.Get("monitoring_role_arn").(string))
-		requestUpdate = true
-	}
+	err := RunClusterInstanceUpdatesConcurrently(func() error {
+		return resourceClusterInstanceUpdateInstance(d, conn)
+	}, updateTags)
 
-	if d.HasChanges("performance_insights_enabled", "performance_insights_kms_key_id", "performance_insights_retention_period") {
-		req.EnablePerformanceInsights = aws.Bool(d.Get("performance_insights_enabled").(bool))
+	if err != nil {
+		return err
+	}
 
-		if v, ok := d.GetOk("performance_insights_kms_key_id"); ok {
-			req.PerformanceInsightsKMSKeyId = aws.String(v.(string))
-		}
+	clusterInstanceDBClusters.Invalidate(conn, d.Get("cluster_identifier").(string))
 
-		if v, ok := d.GetOk("performance_insights_retention_period"); ok {
-			req.PerformanceInsightsRetentionPeriod = aws.Int64(int64(v.(int)))
+	return resourceClusterInstanceRead(d, meta)
+}
+
+// resourceClusterInstanceUpdateInstance applies the changes to the instance other than to its tags.
+func resourceClusterInstanceUpdateInstance(d *schema.ResourceData, conn *rds.RDS) error {
+	applyImmediately := d.Get("apply_immediately").(bool)
+	applyImmediatelyOverrides := d.Get("apply_immediately_overrides").(map[string]interface{})
+
+	// Modifications are split by whether they are applied immediately or during the next maintenance window.
+	immediateReq := &rds.ModifyDBInstanceInput{
+		ApplyImmediately:     aws.Bool(true),
+		DBInstanceIdentifier: aws.String(d.Id()),
+	}
+	deferredReq := &rds.ModifyDBInstanceInput{
+		ApplyImmediately:     aws.Bool(false),
+		DBInstanceIdentifier: aws.String(d.Id()),
+	}
+	var requestImmediate, requestDeferred bool
+
+	// modifyRequest returns the request for modifying the specified attributes, which are sent together.
+	modifyRequest := func(keys ...string) *rds.ModifyDBInstanceInput {
+		var changed []string
+		for _, key := range keys {
+			if d.HasChange(key) {
+				changed = append(changed, key)
+			}
+		}
+
+		if clusterInstanceApplyImmediately(applyImmediately, applyImmediatelyOverrides, changed...) {
+			requestImmediate = true
+			return immediateReq
+		}
+
+		requestDeferred = true
+		return deferredReq
+	}
+
+	if d.HasChange("db_parameter_group_name") {
+		// Checked before modifying as an incompatible DB parameter group only fails the modification once it's underway.
+		if err := ValidateClusterInstanceDBParameterGroup(conn, d.Get("db_parameter_group_name").(string), d.Get("engine").(string), d.Get("engine_version_actual").(string)); err != nil {
+			return fmt.Errorf("error modifying RDS Cluster Instance (%s): %w", d.Id(), err)
+		}
+
+		modifyRequest("db_parameter_group_name").DBParameterGroupName = aws.String(d.Get("db_parameter_group_name").(string))
+	}
+
+	if d.HasChange("instance_class") {
+		modifyRequest("instance_class").DBInstanceClass = aws.String(d.Get("instance_class").(string))
+	}
+
+	if d.HasChange("monitoring_role_arn") {
+		modifyRequest("monitoring_interval", "monitoring_role_arn").MonitoringRoleArn = aws.String(d.Get("monitoring_role_arn").(string))
+	}
+
+	if d.HasChanges("performance_insights_enabled", "performance_insights_kms_key_id", "performance_insights_retention_period") {
+		performanceInsightsEnabled := d.Get("performance_insights_enabled").(bool)
+
+		if d.HasChange("performance_insights_retention_period") && !d.GetRawConfig().GetAttr("performance_insights_retention_period").IsNull() {
+			if err := validateClusterInstancePerformanceInsightsRetentionPeriod(performanceInsightsEnabled, d.Get("performance_insights_retention_period").(int)); err != nil {
+				return fmt.Errorf("error modifying RDS Cluster Instance (%s): %w", d.Id(), err)
+			}
+		}
+
+		req := modifyRequest("performance_insights_enabled", "performance_insights_kms_key_id", "performance_insights_retention_period")
+		req.EnablePerformanceInsights = aws.Bool(performanceInsightsEnabled)
+
+		// Only send the KMS key and retention period when they change or Performance Insights is being toggled,
+		// RDS may reject an unchanged KMS key.
+		if d.HasChanges("performance_insights_enabled", "performance_insights_kms_key_id") {
+			if v, ok := d.GetOk("performance_insights_kms_key_id"); ok {
+				req.PerformanceInsightsKMSKeyId = aws.String(v.(string))
+			}
 		}
 
-		requestUpdate = true
+		// RDS rejects a retention period when Performance Insights is disabled.
+		if performanceInsightsEnabled && d.HasChanges("performance_insights_enabled", "performance_insights_retention_period") {
+			if v, ok := d.GetOk("performance_insights_retention_period"); ok {
+				req.PerformanceInsightsRetentionPeriod = aws.Int64(int64(v.(int)))
+			}
+		}
 	}
 
 	if d.HasChange("preferred_backup_window") {
-		req.PreferredBackupWindow = aws.String(d.Get("preferred_backup_window").(string))
-		requestUpdate = true
+		modifyRequest("preferred_backup_window").PreferredBackupWindow = aws.String(d.Get("preferred_backup_window").(string))
 	}
 
 	if d.HasChange("preferred_maintenance_window") {
-		req.PreferredMaintenanceWindow = aws.String(d.Get("preferred_maintenance_window").(string))
-		requestUpdate = true
+		modifyRequest("preferred_maintenance_window").PreferredMaintenanceWindow = aws.String(d.Get("preferred_maintenance_window").(string))
 	}
 
 	if d.HasChange("monitoring_interval") {
+		req := modifyRequest("monitoring_interval", "monitoring_role_arn")
 		req.MonitoringInterval = aws.Int64(int64(d.Get("monitoring_interval").(int)))
-		requestUpdate = true
+
+		// RDS rejects a monitoring role when Enhanced Monitoring is being disabled.
+		if d.Get("monitoring_interval").(int) == 0 && d.GetRawConfig().GetAttr("monitoring_role_arn").IsNull() {
+			req.MonitoringRoleArn = aws.String("")
+		}
 	}
 
+	// Enabling automatic minor version upgrades while engine_version changes could upgrade the instance
+	// past the requested version, so they are only enabled once the instance runs the requested version.
+	var enableAutoMinorVersionUpgrade bool
 	if d.HasChange("auto_minor_version_upgrade") {
-		req.AutoMinorVersionUpgrade = aws.Bool(d.Get("auto_minor_version_upgrade").(bool))
-		requestUpdate = true
+		if d.Get("auto_minor_version_upgrade").(bool) && d.HasChange("engine_version") && d.Get("engine_version").(string) != "" && d.Get("engine_version").(string) != engineVersionLatest {
+			enableAutoMinorVersionUpgrade = true
+		} else {
+			modifyRequest("auto_minor_version_upgrade").AutoMinorVersionUpgrade = aws.Bool(d.Get("auto_minor_version_upgrade").(bool))
+		}
 	}
 
 	if d.HasChange("copy_tags_to_snapshot") {
-		req.CopyTagsToSnapshot = aws.Bool(d.Get("copy_tags_to_snapshot").(bool))
-		requestUpdate = true
+		modifyRequest("copy_tags_to_snapshot").CopyTagsToSnapshot = aws.Bool(d.Get("copy_tags_to_snapshot").(bool))
 	}
 
 	if d.HasChange("promotion_tier") {
-		req.PromotionTier = aws.Int64(int64(d.Get("promotion_tier").(int)))
-		requestUpdate = true
+		modifyRequest("promotion_tier").PromotionTier = aws.Int64(int64(d.Get("promotion_tier").(int)))
 	}
 
 	if d.HasChange("publicly_accessible") {
-		req.PubliclyAccessible = aws.Bool(d.Get("publicly_accessible").(bool))
-		requestUpdate = true
+		modifyRequest("publicly_accessible").PubliclyAccessible = aws.Bool(d.Get("publicly_accessible").(bool))
 	}
 
 	if d.HasChange("ca_cert_identifier") {
-		req.CACertificateIdentifier = aws.String(d.Get("ca_cert_identifier").(string))
-		requestUpdate = true
+		modifyRequest("ca_cert_identifier").CACertificateIdentifier = aws.String(d.Get("ca_cert_identifier").(string))
+	}
+
+	if d.HasChange("port") {
+		modifyRequest("port").DBPortNumber = aws.Int64(int64(d.Get("port").(int)))
+	}
+
+	// The DB cluster owns the engine version, so the instance only reaches the requested version if the cluster does.
+	if enableAutoMinorVersionUpgrade {
+		dbClusterID := d.Get("cluster_identifier").(string)
+		engineVersion := d.Get("engine_version").(string)
+
+		dbCluster, err := FindDBClusterByID(conn, dbClusterID)
+
+		if err != nil {
+			return fmt.Errorf("error reading RDS Cluster (%s): %w", dbClusterID, err)
+		}
+
+		if !dbClusterEngineVersionApplying(dbCluster, engineVersion) {
+			return fmt.Errorf("error modifying RDS Cluster Instance (%s): RDS Cluster (%s) runs engine version %s and isn't being upgraded to engine_version (%s). Change the engine_version of the cluster instead", d.Id(), dbClusterID, aws.StringValue(dbCluster.EngineVersion), engineVersion)
+		}
+	}
+
+	// Modifications deferred to the maintenance window are allowed during a change freeze, all other changes to the instance aren't.
+	var maintenanceActionOptInType string
+	if d.HasChange("apply_maintenance_action") {
+		maintenanceActionOptInType = d.Get("apply_maintenance_action").(string)
+	}
+
+	if clusterInstanceUpdateAppliesImmediately(requestImmediate, enableAutoMinorVersionUpgrade, d.HasChange("reboot_trigger"), maintenanceActionOptInType) {
+		if err := validateClusterInstanceChangeFreeze(d.Get("change_freeze").([]interface{}), time.Now()); err != nil {
+			return fmt.Errorf("error modifying RDS Cluster Instance (%s): %w", d.Id(), err)
+		}
 	}
 
-	log.Printf("[DEBUG] Send DB Instance Modification request: %#v", requestUpdate)
-	if requestUpdate {
+	// Modifications applied immediately also apply any pending ones, so they are requested first.
+	var configuringLogExports bool
+	var reqs []*rds.ModifyDBInstanceInput
+	if requestImmediate {
+		reqs = append(reqs, immediateReq)
+	}
+	if requestDeferred {
+		reqs = append(reqs, deferredReq)
+	}
+
+	for _, req := range reqs {
+		// A prior modification that is being applied immediately must finish before another can be requested.
+		// Modifications deferred to the maintenance window don't block a new request, so they aren't waited for.
+		if aws.BoolValue(req.ApplyImmediately) {
+			if _, err := waitDBInstanceAvailable(conn, d.Id(), d.Timeout(schema.TimeoutUpdate)); err != nil {
+				return fmt.Errorf("error waiting for RDS Cluster Instance (%s) to be available: %w", d.Id(), err)
+			}
+		}
+
 		log.Printf("[DEBUG] DB Instance Modification request: %#v", req)
-		err := resource.Retry(propagationTimeout, func() *resource.RetryError {
+		err := RetryClusterInstanceIAMPropagation(clusterInstanceMonitoringRoleIAMPropagationTimeout(d.Get("monitoring_role_ready").(bool)), func() error {
 			_, err := conn.ModifyDBInstance(req)
-			if err != nil {
-				if tfawserr.ErrMessageContains(err, "InvalidParameterValue", "IAM role ARN value is invalid or does not include the required permissions") {
-					return resource.RetryableError(err)
-				}
-				return resource.NonRetryableError(err)
-			}
-			return nil
+			return err
 		})
-		if tfresource.TimedOut(err) {
-			_, err = conn.ModifyDBInstance(req)
-		}
 
 		if err != nil {
-			return fmt.Errorf("error modifying RDS Cluster Instance (%s): %w", d.Id(), err)
+			return fmt.Errorf("error modifying RDS Cluster Instance (%s): %w", d.Id(), ClusterInstanceModifyError(err, d.Get("db_parameter_group_name").(string)))
 		}
 
 		// reuse db_instance refresh func
+		delay, minTimeout := clusterInstancePollDelays()
 		stateConf := &resource.StateChangeConf{
 			Pending:    resourceClusterInstanceCreateUpdatePendingStates,
 			Target:     []string{"available"},
-			Refresh:    resourceDBInstanceStateRefreshFunc(d.Id(), conn),
+			Refresh:    statusDBInstanceRecordState(resourceDBInstanceStateRefreshFunc(d.Id(), conn), InstanceStatusConfiguringLogExports, &configuringLogExports),
 			Timeout:    d.Timeout(schema.TimeoutUpdate),
-			MinTimeout: 10 * time.Second,
-			Delay:      30 * time.Second, // Wait 30 secs before starting
+			MinTimeout: minTimeout,
+			Delay:      delay,
 		}
 
 		// Wait, catching any errors
@@ -622,29 +1462,105 @@ func resourceClusterInstanceUpdate(d *schema.ResourceData, meta interface{}) err
 		if err != nil {
 			return err
 		}
+	}
+
+	// The instance only configures log exports when the DB cluster's log exports change.
+	if configuringLogExports {
+		if err := resourceClusterInstanceWaitCloudwatchLogsExportsApplied(d, conn); err != nil {
+			return err
+		}
+	}
 
+	if v := immediateReq.CACertificateIdentifier; v != nil {
+		caCertificateIdentifier := aws.StringValue(v)
+
+		if _, err := waitDBInstanceCACertificateIdentifierApplied(conn, d.Id(), caCertificateIdentifier, d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return fmt.Errorf("error waiting for RDS Cluster Instance (%s) CA certificate (%s): %w", d.Id(), caCertificateIdentifier, err)
+		}
 	}
 
-	if d.HasChange("tags_all") {
-		o, n := d.GetChange("tags_all")
+	// The promotion tier isn't a pending modification, RDS applies it right away.
+	if d.HasChange("promotion_tier") {
+		promotionTier := d.Get("promotion_tier").(int)
 
-		if err := UpdateTags(conn, d.Get("arn").(string), o, n); err != nil {
-			return fmt.Errorf("error updating RDS Cluster Instance (%s) tags: %w", d.Id(), err)
+		// State records the reported promotion tier either way, so a promotion tier that still isn't reported doesn't fail the update.
+		if _, err := waitDBInstancePromotionTierApplied(conn, d.Id(), int64(promotionTier), clusterInstancePromotionTierAppliedTimeout); err != nil {
+			log.Printf("[WARN] RDS Cluster Instance (%s) promotion tier (%d) not yet reported: %s", d.Id(), promotionTier, err)
 		}
 	}
 
-	return resourceClusterInstanceRead(d, meta)
+	if enableAutoMinorVersionUpgrade {
+		engineVersion := d.Get("engine_version").(string)
+
+		if _, err := waitDBInstanceEngineVersionSatisfied(conn, d.Id(), engineVersion, d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return fmt.Errorf("error waiting for RDS Cluster Instance (%s) engine version (%s): %w", d.Id(), engineVersion, err)
+		}
+
+		input := &rds.ModifyDBInstanceInput{
+			ApplyImmediately:        aws.Bool(true),
+			AutoMinorVersionUpgrade: aws.Bool(true),
+			DBInstanceIdentifier:    aws.String(d.Id()),
+		}
+
+		log.Printf("[DEBUG] Enabling RDS Cluster Instance (%s) auto minor version upgrade: %s", d.Id(), input)
+		if _, err := conn.ModifyDBInstance(input); err != nil {
+			return fmt.Errorf("error enabling RDS Cluster Instance (%s) auto minor version upgrade: %w", d.Id(), err)
+		}
+
+		if err := waitUntilDBInstanceAvailableAfterUpdate(d.Id(), conn, d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return fmt.Errorf("error waiting for RDS Cluster Instance (%s) to be available: %w", d.Id(), err)
+		}
+	}
+
+	// Any change of the reboot_trigger token reboots the instance, its value has no other meaning.
+	if d.HasChange("reboot_trigger") {
+		input := &rds.RebootDBInstanceInput{
+			DBInstanceIdentifier: aws.String(d.Id()),
+		}
+
+		log.Printf("[DEBUG] Rebooting RDS Cluster Instance: %s", input)
+		if _, err := conn.RebootDBInstance(input); err != nil {
+			return fmt.Errorf("error rebooting RDS Cluster Instance (%s): %w", d.Id(), err)
+		}
+
+		if err := waitUntilDBInstanceAvailableAfterUpdate(d.Id(), conn, d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return fmt.Errorf("error waiting for RDS Cluster Instance (%s) to be available: %w", d.Id(), err)
+		}
+	}
+
+	if v := d.Get("apply_maintenance_action").(string); d.HasChange("apply_maintenance_action") && v != "" {
+		if err := resourceClusterInstanceApplyPendingMaintenanceActions(conn, d.Id(), d.Get("arn").(string), v); err != nil {
+			return err
+		}
+	}
+
+	// copy_tags_to_snapshot only applies to snapshots taken after it's enabled.
+	if d.HasChange("copy_tags_to_snapshot") && d.Get("copy_tags_to_snapshot").(bool) && d.Get("retroactively_tag_snapshots").(bool) {
+		if err := TagClusterInstanceSnapshots(conn, d.Id(), tftags.New(d.Get("tags_all").(map[string]interface{}))); err != nil {
+			return fmt.Errorf("error tagging RDS Cluster Instance (%s) snapshots: %w", d.Id(), err)
+		}
+	}
+
+	return nil
 }
 
 func resourceClusterInstanceDelete(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*conns.AWSClient).RDSConn
 
-	input := &rds.DeleteDBInstanceInput{
-		DBInstanceIdentifier: aws.String(d.Id()),
+	input, warnings, err := clusterInstanceDeleteInput(conn, expandClusterInstanceDeleteOptions(d))
+
+	if err != nil {
+		return err
+	}
+
+	for _, warning := range warnings {
+		log.Printf("[WARN] %s", warning)
 	}
 
+	finalSnapshotID := aws.StringValue(input.FinalDBSnapshotIdentifier)
+
 	log.Printf("[DEBUG] Deleting RDS Cluster Instance: %s", d.Id())
-	_, err := tfresource.RetryWhen(
+	_, err = tfresource.RetryWhen(
 		d.Timeout(schema.TimeoutDelete),
 		func() (interface{}, error) {
 			return conn.DeleteDBInstance(input)
@@ -658,6 +1574,41 @@ func resourceClusterInstanceDelete(d *schema.ResourceData, meta interface{}) err
 		},
 	)
 
+	if IsClusterInstancePrimaryDeleteError(err) {
+		dbClusterID := d.Get("cluster_identifier").(string)
+
+		if !d.Get("auto_failover_before_delete").(bool) {
+			return fmt.Errorf("RDS Cluster Instance (%s) is the primary instance of RDS Cluster (%s). Fail over the cluster before deleting the instance, or set auto_failover_before_delete to true: %w", d.Id(), dbClusterID, err)
+		}
+
+		log.Printf("[DEBUG] Failing over RDS Cluster (%s) before deleting RDS Cluster Instance (%s)", dbClusterID, d.Id())
+		_, err = conn.FailoverDBCluster(&rds.FailoverDBClusterInput{
+			DBClusterIdentifier: aws.String(dbClusterID),
+		})
+
+		if err != nil {
+			return fmt.Errorf("error failing over RDS Cluster (%s): %w", dbClusterID, err)
+		}
+
+		clusterInstanceDBClusters.Invalidate(conn, dbClusterID)
+
+		if _, err := waitDBClusterWriterChanged(conn, dbClusterID, d.Id(), d.Timeout(schema.TimeoutDelete)); err != nil {
+			return fmt.Errorf("error waiting for RDS Cluster (%s) failover: %w", dbClusterID, err)
+		}
+
+		_, err = conn.DeleteDBInstance(input)
+	}
+
+	if finalSnapshotID != "" && tfawserr.ErrCodeEquals(err, rds.ErrCodeSnapshotQuotaExceededFault) {
+		if !d.Get("skip_final_snapshot_on_quota_exceeded").(bool) {
+			return fmt.Errorf("error deleting RDS Cluster Instance (%s): final snapshot (%s) would exceed the DB snapshot quota. Delete DB snapshots that are no longer needed or request a quota increase, or set skip_final_snapshot or skip_final_snapshot_on_quota_exceeded to true: %w", d.Id(), finalSnapshotID, err)
+		}
+
+		log.Printf("[WARN] RDS Cluster Instance (%s) final snapshot (%s) would exceed the DB snapshot quota, deleting without a final snapshot", d.Id(), finalSnapshotID)
+		finalSnapshotID = ""
+		err = DeleteClusterInstanceWithoutFinalSnapshot(conn, input)
+	}
+
 	if tfawserr.ErrCodeEquals(err, rds.ErrCodeDBInstanceNotFoundFault) {
 		return nil
 	}
@@ -666,31 +1617,1058 @@ func resourceClusterInstanceDelete(d *schema.ResourceData, meta interface{}) err
 		return fmt.Errorf("error deleting RDS Cluster Instance (%s): %w", d.Id(), err)
 	}
 
-	if _, err := waitDBClusterInstanceDeleted(conn, d.Id(), d.Timeout(schema.TimeoutDelete)); err != nil {
+	// RDS only copies tags to the final snapshot when copy_tags_to_snapshot is true.
+	if finalSnapshotID != "" && !d.Get("copy_tags_to_snapshot").(bool) {
+		if tags := d.Get("tags_all").(map[string]interface{}); len(tags) > 0 {
+			// The final snapshot is created asynchronously.
+			outputRaw, err := tfresource.RetryWhenNotFound(propagationTimeout, func() (interface{}, error) {
+				return FindDBSnapshotByID(conn, finalSnapshotID)
+			})
+
+			if err != nil {
+				return fmt.Errorf("error reading RDS Cluster Instance (%s) final snapshot (%s): %w", d.Id(), finalSnapshotID, err)
+			}
+
+			if err := UpdateTags(conn, aws.StringValue(outputRaw.(*rds.DBSnapshot).DBSnapshotArn), nil, tags); err != nil {
+				return fmt.Errorf("error adding tags to RDS Cluster Instance (%s) final snapshot (%s): %w", d.Id(), finalSnapshotID, err)
+			}
+		}
+	}
+
+	if d.Get("skip_delete_wait").(bool) {
+		log.Printf("[INFO] Skipping wait for RDS Cluster Instance (%s) delete", d.Id())
+	} else if _, err := waitDBClusterInstanceDeleted(conn, d.Id(), d.Timeout(schema.TimeoutDelete)); err != nil {
 		return fmt.Errorf("error waiting for RDS Cluster Instance (%s) delete: %w", d.Id(), err)
 	}
 
+	clusterInstanceDBClusters.Invalidate(conn, d.Get("cluster_identifier").(string))
+
 	return nil
 }
 
-var resourceClusterInstanceCreateUpdatePendingStates = []string{
-	"backing-up",
-	"configuring-enhanced-monitoring",
-	"configuring-iam-database-auth",
-	"configuring-log-exports",
-	"creating",
-	"maintenance",
-	"modifying",
-	"rebooting",
-	"renaming",
-	"resetting-master-credentials",
-	"starting",
-	"storage-optimization",
-	"upgrading",
+// ClusterInstanceDeleteOptions are the arguments of an RDS Cluster Instance that decide how it is deleted.
+type ClusterInstanceDeleteOptions struct {
+	ID                                              string
+	DBClusterID                                     string
+	Engine                                          string
+	FinalSnapshotIdentifier                         string
+	PreventLastInstanceDeleteWithDeletionProtection bool
+	PreventWriterDeleteWithReaders                  bool
+	ProxyTargetDeleteCheck                          string
+	SkipFinalSnapshot                               bool
 }
 
-func clusterSetResourceDataEngineVersionFromClusterInstance(d *schema.ResourceData, c *rds.DBInstance) {
-	oldVersion := d.Get("engine_version").(string)
-	newVersion := aws.StringValue(c.EngineVersion)
-	compareActualEngineVersion(d, oldVersion, newVersion)
+func expandClusterInstanceDeleteOptions(d *schema.ResourceData) *ClusterInstanceDeleteOptions {
+	return &ClusterInstanceDeleteOptions{
+		ID:                      d.Id(),
+		DBClusterID:             d.Get("cluster_identifier").(string),
+		Engine:                  d.Get("engine").(string),
+		FinalSnapshotIdentifier: d.Get("final_snapshot_identifier").(string),
+		PreventLastInstanceDeleteWithDeletionProtection: d.Get("prevent_last_instance_delete_with_deletion_protection").(bool),
+		PreventWriterDeleteWithReaders:                  d.Get("prevent_writer_delete_with_readers").(bool),
+		ProxyTargetDeleteCheck:                          d.Get("proxy_target_delete_check").(string),
+		SkipFinalSnapshot:                               d.Get("skip_final_snapshot").(bool),
+	}
+}
+
+// clusterInstanceDeleteInput runs the checks that the specified options enable before an RDS Cluster Instance is deleted,
+// and returns the DeleteDBInstance request and the warnings of the checks. An error means the instance mustn't be deleted.
+func clusterInstanceDeleteInput(conn rdsiface.RDSAPI, options *ClusterInstanceDeleteOptions) (*rds.DeleteDBInstanceInput, []string, error) {
+	input := &rds.DeleteDBInstanceInput{
+		DBInstanceIdentifier: aws.String(options.ID),
+	}
+
+	// Final snapshots of Aurora DB instances are taken at the cluster level.
+	if !isAuroraEngine(options.Engine) {
+		if options.SkipFinalSnapshot {
+			input.SkipFinalSnapshot = aws.Bool(true)
+		} else {
+			input.SkipFinalSnapshot = aws.Bool(false)
+
+			if options.FinalSnapshotIdentifier == "" {
+				return nil, nil, fmt.Errorf("final_snapshot_identifier is required when skip_final_snapshot is false")
+			}
+
+			input.FinalDBSnapshotIdentifier = aws.String(options.FinalSnapshotIdentifier)
+		}
+	}
+
+	if options.PreventWriterDeleteWithReaders || options.PreventLastInstanceDeleteWithDeletionProtection {
+		dbCluster, err := FindDBClusterByID(conn, options.DBClusterID)
+
+		if err != nil && !tfresource.NotFound(err) {
+			return nil, nil, fmt.Errorf("error reading RDS Cluster (%s): %w", options.DBClusterID, err)
+		}
+
+		if options.PreventWriterDeleteWithReaders && dbCluster != nil && ClusterInstanceIsWriterWithReaders(options.ID, dbCluster) {
+			return nil, nil, fmt.Errorf("RDS Cluster Instance (%s) is the writer of RDS Cluster (%s), which has reader instances. Fail over the cluster before deleting the writer, or set prevent_writer_delete_with_readers to false", options.ID, options.DBClusterID)
+		}
+
+		if options.PreventLastInstanceDeleteWithDeletionProtection && dbCluster != nil && ClusterInstanceIsLastOfProtectedCluster(options.ID, dbCluster) {
+			return nil, nil, fmt.Errorf("RDS Cluster Instance (%s) is the last instance of RDS Cluster (%s), which has deletion protection enabled. Disable the cluster's deletion protection before deleting its last instance, or set prevent_last_instance_delete_with_deletion_protection to false", options.ID, options.DBClusterID)
+		}
+	}
+
+	var warnings []string
+
+	if check := options.ProxyTargetDeleteCheck; check != "" {
+		proxyNames, err := FindDBProxyNamesByTargetDBInstanceID(conn, options.ID)
+
+		if err != nil {
+			return nil, nil, fmt.Errorf("error checking whether RDS Cluster Instance (%s) is an RDS DB Proxy target: %w", options.ID, err)
+		}
+
+		if len(proxyNames) > 0 {
+			msg := fmt.Sprintf("RDS Cluster Instance (%s) is a target of RDS DB Proxies (%s), deleting it can break their routing", options.ID, strings.Join(proxyNames, ", "))
+
+			if check == ProxyTargetDeleteCheckError {
+				return nil, nil, fmt.Errorf("%s. Deregister it from the proxies before deleting it, or set proxy_target_delete_check to %q", msg, ProxyTargetDeleteCheckWarn)
+			}
+
+			warnings = append(warnings, msg)
+		}
+	}
+
+	return input, warnings, nil
+}
+
+func resourceClusterInstanceCustomizeDiffEngine(_ context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if diff.Id() != "" || diff.Get("engine").(string) != EngineAurora {
+		return nil
+	}
+
+	if diff.Get("deprecated_engine_error").(bool) {
+		return errors.New(deprecatedEngineAuroraMessage("engine"))
+	}
+
+	// engine may be unset and defaulted, in which case it isn't validated and no warning is shown.
+	log.Printf("[WARN] %s", deprecatedEngineAuroraMessage("engine"))
+
+	return nil
+}
+
+func resourceClusterInstanceCustomizeDiffPubliclyAccessible(_ context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if !diff.HasChange("publicly_accessible") || !diff.Get("publicly_accessible").(bool) {
+		return nil
+	}
+
+	// allow_public_access has no default, so whether it's set is read from the configuration.
+	v := diff.GetRawConfig().GetAttr("allow_public_access")
+
+	if !v.IsKnown() {
+		return nil
+	}
+
+	var allowPublicAccess *bool
+	if !v.IsNull() {
+		allowPublicAccess = aws.Bool(v.True())
+	}
+
+	warnings, err := validateClusterInstancePubliclyAccessible(allowPublicAccess)
+
+	for _, warning := range warnings {
+		log.Printf("[WARN] RDS Cluster Instance (%s): %s", diff.Id(), warning)
+	}
+
+	return err
+}
+
+func resourceClusterInstanceCustomizeDiffMonitoringInterval(_ context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	// Deferring modifications only applies to existing instances.
+	if diff.Id() == "" || !diff.HasChange("monitoring_interval") {
+		return nil
+	}
+
+	// monitoring_interval and monitoring_role_arn are modified together, see resourceClusterInstanceUpdateInstance.
+	changed := []string{"monitoring_interval"}
+	if diff.HasChange("monitoring_role_arn") {
+		changed = append(changed, "monitoring_role_arn")
+	}
+
+	applyImmediately := clusterInstanceApplyImmediately(diff.Get("apply_immediately").(bool), diff.Get("apply_immediately_overrides").(map[string]interface{}), changed...)
+	o, n := diff.GetChange("monitoring_interval")
+
+	for _, warning := range validateClusterInstanceMonitoringInterval(o.(int), n.(int), applyImmediately) {
+		log.Printf("[WARN] RDS Cluster Instance (%s): %s", diff.Id(), warning)
+	}
+
+	return nil
+}
+
+func resourceClusterInstanceCustomizeDiffBackupTarget(_ context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if diff.Id() != "" {
+		return nil
+	}
+
+	v, ok := diff.GetOk("backup_target")
+
+	if !ok {
+		return nil
+	}
+
+	return validateClusterInstanceBackupTarget(diff.Get("engine").(string), v.(string))
+}
+
+func resourceClusterInstanceCustomizeDiffParameterGroupFamily(_ context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if !diff.HasChange("db_parameter_group_name") || !diff.NewValueKnown("db_parameter_group_name") {
+		return nil
+	}
+
+	name := diff.Get("db_parameter_group_name").(string)
+
+	if name == "" {
+		return nil
+	}
+
+	conn := meta.(*conns.AWSClient).RDSConn
+
+	dbParameterGroup, err := FindDBParameterGroupByName(conn, name)
+
+	// The parameter group may be created in the same apply.
+	if tfresource.NotFound(err) {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("error reading RDS DB Parameter Group (%s): %w", name, err)
+	}
+
+	var engineVersion string
+	if diff.NewValueKnown("engine_version") && diff.Get("engine_version").(string) != engineVersionLatest {
+		engineVersion = diff.Get("engine_version").(string)
+	}
+
+	return validateClusterInstanceParameterGroupFamily(diff.Get("engine").(string), engineVersion, aws.StringValue(dbParameterGroup.DBParameterGroupFamily))
+}
+
+func resourceClusterInstanceCustomizeDiffPort(_ context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if diff.GetRawConfig().GetAttr("port").IsNull() {
+		return nil
+	}
+
+	return validateClusterInstancePort(diff.Get("engine").(string))
+}
+
+func resourceClusterInstanceCustomizeDiffPerformanceInsightsKMSKeyID(_ context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if !diff.HasChange("performance_insights_kms_key_id") || !diff.NewValueKnown("performance_insights_kms_key_id") {
+		return nil
+	}
+
+	v := diff.Get("performance_insights_kms_key_id").(string)
+
+	if v == "" {
+		return nil
+	}
+
+	return validateClusterInstancePerformanceInsightsKMSKeyID(v, meta.(*conns.AWSClient).Region)
+}
+
+func resourceClusterInstanceCustomizeDiffTags(_ context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if !diff.NewValueKnown("tags_all") {
+		return nil
+	}
+
+	return validateTags(tftags.New(diff.Get("tags_all").(map[string]interface{})))
+}
+
+func resourceClusterInstanceCustomizeDiffFinalSnapshot(_ context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	return validateClusterInstanceFinalSnapshot(diff.Get("engine").(string), diff.Get("skip_final_snapshot").(bool), diff.Get("final_snapshot_identifier").(string))
+}
+
+// clusterInstanceMonitoringRoleIAMPropagationTimeout returns how long cluster instance creates and updates are retried
+// while the IAM role is not yet usable by RDS. A role that is known to have propagated isn't waited for.
+func clusterInstanceMonitoringRoleIAMPropagationTimeout(ready bool) time.Duration {
+	if ready {
+		return 0
+	}
+
+	return clusterInstanceIAMPropagationTimeout
+}
+
+// RetryClusterInstanceIAMPropagation calls f, retrying for up to the specified timeout while RDS
+// reports that an IAM role is invalid, which usually means the role has not propagated yet.
+// With a timeout of zero, f is called once.
+func RetryClusterInstanceIAMPropagation(timeout time.Duration, f func() error) error {
+	if timeout <= 0 {
+		return f()
+	}
+
+	err := resource.Retry(timeout, func() *resource.RetryError {
+		err := f()
+
+		if tfawserr.ErrMessageContains(err, "InvalidParameterValue", "IAM role ARN value is invalid or does not include the required permissions") {
+			return resource.RetryableError(err)
+		}
+
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+
+		return nil
+	})
+
+	if tfresource.TimedOut(err) {
+		err = f()
+	}
+
+	return err
+}
+
+// resourceClusterInstanceWaitCloudwatchLogsExportsApplied waits for the DB cluster's log exports to be applied when they
+// have changed, e.g. by the DB cluster in the same apply. An instance can finish "configuring-log-exports" before the
+// DB cluster's log exports are live.
+func resourceClusterInstanceWaitCloudwatchLogsExportsApplied(d *schema.ResourceData, conn rdsiface.RDSAPI) error {
+	dbClusterID := d.Get("cluster_identifier").(string)
+	dbCluster, err := FindDBClusterByID(conn, dbClusterID)
+
+	if err != nil {
+		return fmt.Errorf("error reading RDS Cluster (%s): %w", dbClusterID, err)
+	}
+
+	logTypes := dbClusterRequestedCloudwatchLogsExports(dbCluster)
+
+	if !dbClusterHasPendingCloudwatchLogsExports(dbCluster) && d.Get("enabled_cloudwatch_logs_exports").(*schema.Set).Equal(flex.FlattenStringSet(aws.StringSlice(logTypes))) {
+		return nil
+	}
+
+	if _, err := waitDBClusterCloudwatchLogsExportsApplied(conn, dbClusterID, logTypes, d.Timeout(schema.TimeoutUpdate)); err != nil {
+		return fmt.Errorf("error waiting for RDS Cluster (%s) CloudWatch Logs exports (%s) to be applied: %w", dbClusterID, strings.Join(logTypes, ", "), err)
+	}
+
+	return nil
+}
+
+func resourceClusterInstanceApplyPendingMaintenanceActions(conn rdsiface.RDSAPI, id, arn, optInType string) error {
+	actions, err := ApplyClusterInstancePendingMaintenanceActions(conn, arn, optInType)
+
+	if err != nil {
+		return fmt.Errorf("error applying RDS Cluster Instance (%s) pending maintenance actions: %w", id, err)
+	}
+
+	if len(actions) == 0 {
+		log.Printf("[DEBUG] RDS Cluster Instance (%s) has no pending maintenance actions to apply", id)
+	} else {
+		log.Printf("[INFO] Applied RDS Cluster Instance (%s) pending maintenance actions (%s): %s", id, optInType, strings.Join(actions, ", "))
+	}
+
+	return nil
+}
+
+// ApplyClusterInstancePendingMaintenanceActions opts in to the maintenance actions that are pending for the resource
+// with the specified ARN with the specified opt-in type, e.g. immediate, and returns the actions that were applied.
+// Actions that already have that opt-in status are skipped, so applying the same opt-in type again is a no-op.
+func ApplyClusterInstancePendingMaintenanceActions(conn rdsiface.RDSAPI, arn, optInType string) ([]string, error) {
+	pendingActions, err := FindPendingMaintenanceActionsByResourceARN(conn, arn)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var actions []string
+
+	for _, pendingAction := range pendingActions {
+		if aws.StringValue(pendingAction.OptInStatus) == optInType {
+			continue
+		}
+
+		action := aws.StringValue(pendingAction.Action)
+		input := &rds.ApplyPendingMaintenanceActionInput{
+			ApplyAction:        aws.String(action),
+			OptInType:          aws.String(optInType),
+			ResourceIdentifier: aws.String(arn),
+		}
+
+		log.Printf("[DEBUG] Applying RDS pending maintenance action: %s", input)
+		if _, err := conn.ApplyPendingMaintenanceAction(input); err != nil {
+			return actions, fmt.Errorf("%s: %w", action, err)
+		}
+
+		actions = append(actions, action)
+	}
+
+	return actions, nil
+}
+
+// TagClusterInstanceSnapshots applies the specified tags to the existing automated DB snapshots of a DB instance.
+// Snapshots of Aurora DB instances are taken at the cluster level, so there are none to tag.
+func TagClusterInstanceSnapshots(conn rdsiface.RDSAPI, id string, tags tftags.KeyValueTags) error {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	input := &rds.DescribeDBSnapshotsInput{
+		DBInstanceIdentifier: aws.String(id),
+		SnapshotType:         aws.String(snapshotTypeAutomated),
+	}
+	var arns []string
+
+	err := conn.DescribeDBSnapshotsPages(input, func(page *rds.DescribeDBSnapshotsOutput, lastPage bool) bool {
+		if page == nil {
+			return !lastPage
+		}
+
+		for _, v := range page.DBSnapshots {
+			if v != nil {
+				arns = append(arns, aws.StringValue(v.DBSnapshotArn))
+			}
+		}
+
+		return !lastPage
+	})
+
+	if err != nil {
+		return fmt.Errorf("error reading RDS DB Snapshots: %w", err)
+	}
+
+	for _, arn := range arns {
+		log.Printf("[DEBUG] Tagging RDS DB Snapshot (%s)", arn)
+		if err := UpdateTags(conn, arn, nil, tags.Map()); err != nil {
+			return fmt.Errorf("error tagging RDS DB Snapshot (%s): %w", arn, err)
+		}
+	}
+
+	return nil
+}
+
+// FindDBProxyNamesByTargetDBInstanceID returns the names of the DB proxies that have the specified DB instance as a target.
+func FindDBProxyNamesByTargetDBInstanceID(conn rdsiface.RDSAPI, id string) ([]string, error) {
+	var proxyNames []string
+
+	err := conn.DescribeDBProxiesPages(&rds.DescribeDBProxiesInput{}, func(page *rds.DescribeDBProxiesOutput, lastPage bool) bool {
+		if page == nil {
+			return !lastPage
+		}
+
+		for _, v := range page.DBProxies {
+			if v != nil {
+				proxyNames = append(proxyNames, aws.StringValue(v.DBProxyName))
+			}
+		}
+
+		return !lastPage
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	var output []string
+
+	for _, proxyName := range proxyNames {
+		var found bool
+
+		err := conn.DescribeDBProxyTargetsPages(&rds.DescribeDBProxyTargetsInput{DBProxyName: aws.String(proxyName)}, func(page *rds.DescribeDBProxyTargetsOutput, lastPage bool) bool {
+			if page == nil {
+				return !lastPage
+			}
+
+			for _, v := range page.Targets {
+				if v != nil && aws.StringValue(v.Type) == rds.TargetTypeRdsInstance && aws.StringValue(v.RdsResourceId) == id {
+					found = true
+					return false
+				}
+			}
+
+			return !lastPage
+		})
+
+		// The proxy may be deleted concurrently.
+		if tfawserr.ErrCodeEquals(err, rds.ErrCodeDBProxyNotFoundFault, rds.ErrCodeDBProxyTargetGroupNotFoundFault) {
+			continue
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("error reading RDS DB Proxy (%s) targets: %w", proxyName, err)
+		}
+
+		if found {
+			output = append(output, proxyName)
+		}
+	}
+
+	return output, nil
+}
+
+// IsClusterInstancePrimaryDeleteError returns whether the specified DeleteDBInstance error
+// indicates that the DB instance is the primary instance of its DB cluster and must be failed over first.
+func IsClusterInstancePrimaryDeleteError(err error) bool {
+	return tfawserr.ErrMessageContains(err, rds.ErrCodeInvalidDBClusterStateFault, "primary instance") ||
+		tfawserr.ErrMessageContains(err, rds.ErrCodeInvalidDBInstanceStateFault, "primary instance")
+}
+
+// ClusterInstanceTags returns the tags of the specified DB instance. The tags returned by DescribeDBInstances
+// are used when present, saving a ListTagsForResource call. The describe result of an untagged DB instance
+// omits its tags, so ListTagsForResource is still called for it, retrying while it's throttled.
+func ClusterInstanceTags(conn rdsiface.RDSAPI, dbInstance *rds.DBInstance) (tftags.KeyValueTags, error) {
+	if dbInstance.TagList != nil {
+		return KeyValueTags(dbInstance.TagList), nil
+	}
+
+	outputRaw, err := tfresource.RetryWhenAWSErrCodeEquals(clusterInstanceTagsThrottleTimeout, func() (interface{}, error) {
+		return ListTags(conn, aws.StringValue(dbInstance.DBInstanceArn))
+	}, errCodeThrottling)
+
+	if err != nil {
+		return tftags.New(nil), err
+	}
+
+	return outputRaw.(tftags.KeyValueTags), nil
+}
+
+// ValidateClusterInstanceDBParameterGroup validates that the family of the named DB parameter group
+// is compatible with the specified engine and engine version.
+func ValidateClusterInstanceDBParameterGroup(conn rdsiface.RDSAPI, name, engine, engineVersion string) error {
+	if name == "" {
+		return nil
+	}
+
+	dbParameterGroup, err := FindDBParameterGroupByName(conn, name)
+
+	if err != nil {
+		return fmt.Errorf("error reading RDS DB Parameter Group (%s): %w", name, err)
+	}
+
+	return validateClusterInstanceParameterGroupFamily(engine, engineVersion, aws.StringValue(dbParameterGroup.DBParameterGroupFamily))
+}
+
+// clusterInstanceDBClusters is shared by the reads of all cluster instances, so that refreshing many instances
+// of the same DB cluster concurrently describes the DB cluster once.
+var clusterInstanceDBClusters = NewClusterInstanceDBClusterCache()
+
+// ClusterInstanceDBClusterCache shares DB cluster describes between concurrent gets: a get of a DB cluster that
+// is being described waits for that describe instead of starting another. It's safe for concurrent use.
+// A DB cluster is only kept until its describe returns, so it's never older than the get, and changes to the
+// DB cluster, e.g. by a failover, are seen by the next get.
+type ClusterInstanceDBClusterCache struct {
+	mu      sync.Mutex
+	entries map[clusterInstanceDBClusterCacheKey]*clusterInstanceDBClusterCacheEntry
+}
+
+// clusterInstanceDBClusterCacheKey includes the connection, as DB cluster identifiers are only unique per account and Region.
+type clusterInstanceDBClusterCacheKey struct {
+	conn rdsiface.RDSAPI
+	id   string
+}
+
+type clusterInstanceDBClusterCacheEntry struct {
+	// done is closed once the DB cluster has been described, after which the other fields are set.
+	done      chan struct{}
+	dbCluster *rds.DBCluster
+	err       error
+}
+
+func NewClusterInstanceDBClusterCache() *ClusterInstanceDBClusterCache {
+	return &ClusterInstanceDBClusterCache{
+		entries: make(map[clusterInstanceDBClusterCacheKey]*clusterInstanceDBClusterCacheEntry),
+	}
+}
+
+// Get returns the specified DB cluster, sharing a describe of it that is in progress. The returned DB cluster must not be modified.
+func (c *ClusterInstanceDBClusterCache) Get(conn rdsiface.RDSAPI, id string) (*rds.DBCluster, error) {
+	key := clusterInstanceDBClusterCacheKey{conn: conn, id: id}
+
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+
+	if ok {
+		c.mu.Unlock()
+		<-entry.done
+
+		return entry.dbCluster, entry.err
+	}
+
+	entry = &clusterInstanceDBClusterCacheEntry{done: make(chan struct{})}
+	c.entries[key] = entry
+	c.mu.Unlock()
+
+	entry.dbCluster, entry.err = FindDBClusterByID(conn, id)
+
+	c.mu.Lock()
+	if c.entries[key] == entry {
+		delete(c.entries, key)
+	}
+	c.mu.Unlock()
+
+	close(entry.done)
+
+	return entry.dbCluster, entry.err
+}
+
+// Invalidate makes the next get of the specified DB cluster describe it again, even if a describe is in progress,
+// e.g. after its membership changed.
+func (c *ClusterInstanceDBClusterCache) Invalidate(conn rdsiface.RDSAPI, id string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, clusterInstanceDBClusterCacheKey{conn: conn, id: id})
+}
+
+// Len returns the number of DB clusters that are being described.
+func (c *ClusterInstanceDBClusterCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return len(c.entries)
+}
+
+// ClusterInstanceEngineVersionUpgradeAvailable returns whether the specified engine version can be upgraded to a newer version.
+func ClusterInstanceEngineVersionUpgradeAvailable(conn rdsiface.RDSAPI, engine, engineVersion string) (bool, error) {
+	input := &rds.DescribeDBEngineVersionsInput{
+		Engine:        aws.String(engine),
+		EngineVersion: aws.String(engineVersion),
+	}
+
+	output, err := findDBEngineVersions(conn, input)
+
+	if err != nil {
+		return false, err
+	}
+
+	for _, v := range output {
+		if len(v.ValidUpgradeTarget) > 0 {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// ClusterInstanceModifyError returns the specified ModifyDBInstance error, explaining a DBUpgradeDependencyFailure
+// error, which RDS returns when a DB parameter group or option group isn't compatible with the engine version.
+func ClusterInstanceModifyError(err error, dbParameterGroupName string) error {
+	if !tfawserr.ErrCodeEquals(err, rds.ErrCodeDBUpgradeDependencyFailureFault) {
+		return err
+	}
+
+	dbParameterGroup := "the DB parameter group"
+	if dbParameterGroupName != "" {
+		dbParameterGroup = fmt.Sprintf("the DB parameter group (%s)", dbParameterGroupName)
+	}
+
+	return fmt.Errorf("%s or the DB cluster's option group is likely not compatible with the engine version. Check that their families match the engine version, e.g. with the aws_rds_engine_version data source's parameter_group_family: %w", dbParameterGroup, err)
+}
+
+// ClusterInstanceCreateError returns the specified CreateDBInstance error, naming the configured attributes that likely
+// caused an InvalidParameterCombination error, which RDS returns when e.g. the engine doesn't support a feature.
+// Attributes that the error message mentions are named if there are any, otherwise all engine-dependent ones that are set.
+func ClusterInstanceCreateError(err error, input *rds.CreateDBInstanceInput) error {
+	if !tfawserr.ErrCodeEquals(err, errCodeInvalidParameterCombination) {
+		return err
+	}
+
+	candidates := []struct {
+		attribute string
+		set       bool
+		keywords  []string
+	}{
+		{"backup_target", input.BackupTarget != nil, []string{"backup target", "outpost"}},
+		{"db_parameter_group_name", input.DBParameterGroupName != nil, []string{"parameter group"}},
+		{"license_model", input.LicenseModel != nil, []string{"license"}},
+		{"monitoring_interval", aws.Int64Value(input.MonitoringInterval) > 0, []string{"monitoring"}},
+		{"performance_insights_enabled", aws.BoolValue(input.EnablePerformanceInsights), []string{"performance insights"}},
+		{"performance_insights_kms_key_id", input.PerformanceInsightsKMSKeyId != nil, []string{"performance insights", "kms"}},
+		{"performance_insights_retention_period", input.PerformanceInsightsRetentionPeriod != nil, []string{"performance insights", "retention"}},
+		{"preferred_backup_window", input.PreferredBackupWindow != nil, []string{"backup window"}},
+	}
+
+	var mentioned, set []string
+	message := ""
+	var awsErr awserr.Error
+	if errors.As(err, &awsErr) {
+		message = strings.ToLower(awsErr.Message())
+	}
+
+	for _, candidate := range candidates {
+		if !candidate.set {
+			continue
+		}
+
+		set = append(set, candidate.attribute)
+
+		for _, keyword := range candidate.keywords {
+			if strings.Contains(message, keyword) {
+				mentioned = append(mentioned, candidate.attribute)
+				break
+			}
+		}
+	}
+
+	if len(mentioned) == 0 {
+		mentioned = set
+	}
+
+	if len(mentioned) == 0 {
+		return err
+	}
+
+	return fmt.Errorf("the combination of engine (%s) and configuration isn't supported, check %s: %w", aws.StringValue(input.Engine), strings.Join(mentioned, ", "), err)
+}
+
+// RunClusterInstanceUpdatesConcurrently runs the specified updates concurrently, skipping nil ones, and waits for all
+// of them to finish. The error of a single failed update is returned as is, those of several failed updates combined.
+func RunClusterInstanceUpdatesConcurrently(updates ...func() error) error {
+	var wg sync.WaitGroup
+	errs := make([]error, len(updates))
+
+	for i, update := range updates {
+		if update == nil {
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int, update func() error) {
+			defer wg.Done()
+
+			errs[i] = update()
+		}(i, update)
+	}
+
+	wg.Wait()
+
+	var result *multierror.Error
+	for _, err := range errs {
+		if err != nil {
+			result = multierror.Append(result, err)
+		}
+	}
+
+	if result != nil && len(result.Errors) == 1 {
+		return result.Errors[0]
+	}
+
+	return result.ErrorOrNil()
+}
+
+// DeleteClusterInstanceWithoutFinalSnapshot deletes a DB instance as specified, but without a final DB snapshot.
+func DeleteClusterInstanceWithoutFinalSnapshot(conn rdsiface.RDSAPI, input *rds.DeleteDBInstanceInput) error {
+	input = &rds.DeleteDBInstanceInput{
+		DBInstanceIdentifier:   input.DBInstanceIdentifier,
+		DeleteAutomatedBackups: input.DeleteAutomatedBackups,
+		SkipFinalSnapshot:      aws.Bool(true),
+	}
+
+	_, err := conn.DeleteDBInstance(input)
+
+	return err
+}
+
+// ClusterInstanceIsWriterWithReaders returns whether the specified DB instance is the writer of
+// the DB cluster and the DB cluster has other members, which deleting the writer would fail over to.
+func ClusterInstanceIsWriterWithReaders(id string, dbCluster *rds.DBCluster) bool {
+	var isWriter bool
+
+	for _, member := range dbCluster.DBClusterMembers {
+		if aws.StringValue(member.DBInstanceIdentifier) == id {
+			isWriter = aws.BoolValue(member.IsClusterWriter)
+		}
+	}
+
+	return isWriter && len(dbCluster.DBClusterMembers) > 1
+}
+
+// ClusterInstanceIsLastOfProtectedCluster returns whether the specified DB instance is the only member of
+// the DB cluster and the DB cluster has deletion protection enabled.
+func ClusterInstanceIsLastOfProtectedCluster(id string, dbCluster *rds.DBCluster) bool {
+	if !aws.BoolValue(dbCluster.DeletionProtection) || len(dbCluster.DBClusterMembers) != 1 {
+		return false
+	}
+
+	return aws.StringValue(dbCluster.DBClusterMembers[0].DBInstanceIdentifier) == id
+}
+
+// DryRunCreateClusterInstance validates that the specified RDS Cluster Instance can be created, without calling
+// CreateDBInstance: its cluster must exist and support instances, and its instance class must be orderable for its
+// engine, engine version and license model, in its Availability Zone if one is specified.
+func DryRunCreateClusterInstance(conn rdsiface.RDSAPI, input *rds.CreateDBInstanceInput) error {
+	if dbClusterID := aws.StringValue(input.DBClusterIdentifier); dbClusterID != "" {
+		dbCluster, err := FindDBClusterByID(conn, dbClusterID)
+
+		if err != nil {
+			return fmt.Errorf("error reading RDS Cluster (%s): %w", dbClusterID, err)
+		}
+
+		if err := validateClusterInstanceClusterEngineMode(dbCluster); err != nil {
+			return err
+		}
+	}
+
+	instanceClass := aws.StringValue(input.DBInstanceClass)
+
+	// The serverless instance class isn't listed in the orderable DB instance options.
+	if instanceClass == instanceClassServerless {
+		return nil
+	}
+
+	engine, engineVersion := aws.StringValue(input.Engine), aws.StringValue(input.EngineVersion)
+	optionsInput := &rds.DescribeOrderableDBInstanceOptionsInput{
+		Engine:        input.Engine,
+		EngineVersion: input.EngineVersion,
+		LicenseModel:  input.LicenseModel,
+	}
+
+	options, err := findOrderableDBInstanceOptions(conn, optionsInput)
+
+	if err != nil {
+		return fmt.Errorf("error reading RDS orderable DB instance options: %w", err)
+	}
+
+	if err := validateClusterInstanceOrderableInstanceClass(instanceClass, engine, engineVersion, options); err != nil {
+		return err
+	}
+
+	availabilityZone := aws.StringValue(input.AvailabilityZone)
+
+	if availabilityZone == "" {
+		return nil
+	}
+
+	for _, option := range options {
+		if aws.StringValue(option.DBInstanceClass) != instanceClass {
+			continue
+		}
+
+		for _, v := range option.AvailabilityZones {
+			if v != nil && aws.StringValue(v.Name) == availabilityZone {
+				return nil
+			}
+		}
+	}
+
+	return fmt.Errorf("instance_class %q is not available in Availability Zone %q", instanceClass, availabilityZone)
+}
+
+// ClusterInstanceDeleteDryRun describes what deleting an RDS Cluster Instance would do.
+type ClusterInstanceDeleteDryRun struct {
+	// ClusterDeleting is whether the instance's cluster is being deleted.
+	ClusterDeleting bool
+	// Error is the error with which the delete would fail before calling DeleteDBInstance, e.g. because
+	// prevent_writer_delete_with_readers is set and the instance is the writer. Errors of DeleteDBInstance aren't predicted.
+	Error error
+	// FinalSnapshotIdentifier is the identifier of the final snapshot that DeleteDBInstance would take, if any.
+	FinalSnapshotIdentifier string
+	// InstanceDeleting is whether the instance is already being deleted. DeleteDBInstance is still called,
+	// the delete ignores the error that the instance is already being deleted and waits for it to disappear.
+	InstanceDeleting bool
+	// InstanceNotFound is whether the instance does not exist. DeleteDBInstance is still called,
+	// the delete ignores the error that the instance isn't found.
+	InstanceNotFound bool
+	// Warnings are the warnings that the delete would log, e.g. when the instance is an RDS DB Proxy target
+	// and proxy_target_delete_check is "warn".
+	Warnings []string
+	// WouldDelete is whether the delete would start deleting the instance.
+	WouldDelete bool
+}
+
+// DryRunDeleteClusterInstance reports what deleting the specified RDS Cluster Instance would do, without calling
+// DeleteDBInstance. It runs the same checks as the delete of the resource. An error means the instance or its cluster
+// couldn't be read.
+func DryRunDeleteClusterInstance(conn rdsiface.RDSAPI, options *ClusterInstanceDeleteOptions) (*ClusterInstanceDeleteDryRun, error) {
+	output := &ClusterInstanceDeleteDryRun{}
+
+	db, err := FindDBInstanceByID(conn, options.ID)
+
+	switch {
+	case tfresource.NotFound(err):
+		output.InstanceNotFound = true
+	case err != nil:
+		return nil, fmt.Errorf("error reading RDS Cluster Instance (%s): %w", options.ID, err)
+	default:
+		output.InstanceDeleting = aws.StringValue(db.DBInstanceStatus) == InstanceStatusDeleting
+	}
+
+	if options.DBClusterID != "" {
+		dbCluster, err := FindDBClusterByID(conn, options.DBClusterID)
+
+		if err != nil && !tfresource.NotFound(err) {
+			return nil, fmt.Errorf("error reading RDS Cluster (%s): %w", options.DBClusterID, err)
+		}
+
+		output.ClusterDeleting = dbCluster != nil && aws.StringValue(dbCluster.Status) == ClusterStatusDeleting
+	}
+
+	input, warnings, err := clusterInstanceDeleteInput(conn, options)
+
+	if err != nil {
+		output.Error = err
+
+		return output, nil
+	}
+
+	output.FinalSnapshotIdentifier = aws.StringValue(input.FinalDBSnapshotIdentifier)
+	output.Warnings = warnings
+	output.WouldDelete = !output.InstanceNotFound && !output.InstanceDeleting
+
+	return output, nil
+}
+
+func resourceClusterInstanceCustomizeDiffCACertIdentifier(_ context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if !diff.HasChange("ca_cert_identifier") || !diff.NewValueKnown("ca_cert_identifier") {
+		return nil
+	}
+
+	id := diff.Get("ca_cert_identifier").(string)
+
+	if id == "" {
+		return nil
+	}
+
+	conn := meta.(*conns.AWSClient).RDSConn
+
+	certificates, err := findCertificates(conn, &rds.DescribeCertificatesInput{})
+
+	if err != nil {
+		return fmt.Errorf("error reading RDS Certificates: %w", err)
+	}
+
+	return validateCertificateIdentifier(id, certificates)
+}
+
+func resourceClusterInstanceCustomizeDiffAvailabilityZone(_ context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if !diff.HasChanges("availability_zone", "db_subnet_group_name") {
+		return nil
+	}
+
+	// Both are computed, so only configured, known values are validated.
+	rawConfig := diff.GetRawConfig()
+	availabilityZone, dbSubnetGroupName := rawConfig.GetAttr("availability_zone"), rawConfig.GetAttr("db_subnet_group_name")
+
+	if !availabilityZone.IsKnown() || availabilityZone.IsNull() || !dbSubnetGroupName.IsKnown() || dbSubnetGroupName.IsNull() {
+		return nil
+	}
+
+	conn := meta.(*conns.AWSClient).RDSConn
+	name := dbSubnetGroupName.AsString()
+
+	dbSubnetGroup, err := FindDBSubnetGroupByName(conn, name)
+
+	// The subnet group may be created in the same apply.
+	if tfresource.NotFound(err) {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("error reading RDS DB Subnet Group (%s): %w", name, err)
+	}
+
+	return validateClusterInstanceAvailabilityZone(availabilityZone.AsString(), dbSubnetGroup)
+}
+
+func resourceClusterInstanceCustomizeDiffServerlessInstanceClass(_ context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if !diff.HasChange("instance_class") || !diff.NewValueKnown("instance_class") || !diff.NewValueKnown("cluster_identifier") {
+		return nil
+	}
+
+	o, n := diff.GetChange("instance_class")
+
+	if o.(string) != instanceClassServerless && n.(string) != instanceClassServerless {
+		return nil
+	}
+
+	conn := meta.(*conns.AWSClient).RDSConn
+	clusterID := diff.Get("cluster_identifier").(string)
+
+	dbCluster, err := FindDBClusterByID(conn, clusterID)
+
+	// The cluster may be created in the same apply.
+	if tfresource.NotFound(err) {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("error reading RDS Cluster (%s): %w", clusterID, err)
+	}
+
+	return validateClusterInstanceServerlessInstanceClass(o.(string), n.(string), dbCluster)
+}
+
+func resourceClusterInstanceCustomizeDiffLicenseModel(_ context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if !diff.HasChange("license_model") || !diff.NewValueKnown("license_model") || !diff.NewValueKnown("engine") {
+		return nil
+	}
+
+	licenseModel := diff.Get("license_model").(string)
+
+	if licenseModel == "" {
+		return nil
+	}
+
+	return validateClusterInstanceLicenseModel(diff.Get("engine").(string), licenseModel)
+}
+
+func resourceClusterInstanceCustomizeDiffOrderableInstanceClass(_ context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if !diff.Get("validate_orderable_instance_class").(bool) {
+		return nil
+	}
+
+	if !diff.HasChange("instance_class") || !diff.NewValueKnown("instance_class") || !diff.NewValueKnown("engine") {
+		return nil
+	}
+
+	instanceClass := diff.Get("instance_class").(string)
+
+	// The serverless instance class isn't listed in the orderable DB instance options.
+	if instanceClass == instanceClassServerless {
+		return nil
+	}
+
+	conn := meta.(*conns.AWSClient).RDSConn
+	engine := diff.Get("engine").(string)
+	input := &rds.DescribeOrderableDBInstanceOptionsInput{
+		Engine: aws.String(engine),
+	}
+
+	var engineVersion string
+	if diff.NewValueKnown("engine_version") && diff.Get("engine_version").(string) != engineVersionLatest {
+		engineVersion = diff.Get("engine_version").(string)
+	}
+
+	if engineVersion != "" {
+		input.EngineVersion = aws.String(engineVersion)
+	}
+
+	options, err := findOrderableDBInstanceOptions(conn, input)
+
+	if err != nil {
+		return fmt.Errorf("error reading RDS orderable DB instance options: %w", err)
+	}
+
+	// The engine version may not be a full version, e.g. "13", which matches no options.
+	if len(options) == 0 {
+		return nil
+	}
+
+	return validateClusterInstanceOrderableInstanceClass(instanceClass, engine, engineVersion, options)
+}
+
+var resourceClusterInstanceCreateUpdatePendingStates = []string{
+	"backing-up",
+	"configuring-enhanced-monitoring",
+	"configuring-iam-database-auth",
+	"configuring-log-exports",
+	"creating",
+	"maintenance",
+	"modifying",
+	"rebooting",
+	"renaming",
+	"resetting-master-credentials",
+	"starting",
+	"storage-optimization",
+	"upgrading",
+}
+
+// clusterSetResourceDataEngineVersionFromClusterInstance sets engine_version_actual
+// to the running engine version reported by the API. engine_version is only
+// updated when the running version is not a patch-level upgrade of the configured
+// (pinned) version, so that automatic minor version upgrades do not cause a diff,
+// and is kept as "latest" when configured so.
+// engine_version_major is set to the major version of the running engine version.
+func clusterSetResourceDataEngineVersionFromClusterInstance(d *schema.ResourceData, c *rds.DBInstance) {
+	oldVersion := d.Get("engine_version").(string)
+	newVersion := aws.StringValue(c.EngineVersion)
+	if oldVersion == engineVersionLatest {
+		d.Set("engine_version_actual", newVersion)
+	} else {
+		compareActualEngineVersion(d, oldVersion, newVersion)
+	}
+	d.Set("engine_version_major", engineVersionMajor(aws.StringValue(c.Engine), newVersion))
 }
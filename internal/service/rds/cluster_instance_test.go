@@ -1,13 +1,21 @@
 package rds_test
 
 import (
+	"errors"
 	"fmt"
+	"reflect"
 	"regexp"
+	"sort"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/rds"
+	"github.com/aws/aws-sdk-go/service/rds/rdsiface"
 	"github.com/hashicorp/aws-sdk-go-base/v2/awsv1shim/v2/tfawserr"
 	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -15,37 +23,2291 @@ import (
 	"github.com/hashicorp/terraform-provider-aws/internal/acctest"
 	"github.com/hashicorp/terraform-provider-aws/internal/conns"
 	tfrds "github.com/hashicorp/terraform-provider-aws/internal/service/rds"
+	tftags "github.com/hashicorp/terraform-provider-aws/internal/tags"
 	"github.com/hashicorp/terraform-provider-aws/internal/tfresource"
 )
 
+type mockClusterInstanceDryRunDeleteConn struct {
+	rdsiface.RDSAPI
+
+	dbClusters []*rds.DBCluster
+	instances  *mockClusterInstancesConn
+	proxies    *mockClusterInstanceProxiesConn
+
+	deleteCalls int
+}
+
+func (m *mockClusterInstanceDryRunDeleteConn) DeleteDBInstance(input *rds.DeleteDBInstanceInput) (*rds.DeleteDBInstanceOutput, error) {
+	m.deleteCalls++
+
+	return &rds.DeleteDBInstanceOutput{}, nil
+}
+
+func (m *mockClusterInstanceDryRunDeleteConn) DescribeDBClusters(input *rds.DescribeDBClustersInput) (*rds.DescribeDBClustersOutput, error) {
+	for _, dbCluster := range m.dbClusters {
+		if aws.StringValue(dbCluster.DBClusterIdentifier) == aws.StringValue(input.DBClusterIdentifier) {
+			return &rds.DescribeDBClustersOutput{DBClusters: []*rds.DBCluster{dbCluster}}, nil
+		}
+	}
+
+	return nil, awserr.New(rds.ErrCodeDBClusterNotFoundFault, "not found", nil)
+}
+
+func (m *mockClusterInstanceDryRunDeleteConn) DescribeDBInstancesPages(input *rds.DescribeDBInstancesInput, fn func(*rds.DescribeDBInstancesOutput, bool) bool) error {
+	return m.instances.DescribeDBInstancesPages(input, fn)
+}
+
+func (m *mockClusterInstanceDryRunDeleteConn) DescribeDBProxiesPages(input *rds.DescribeDBProxiesInput, fn func(*rds.DescribeDBProxiesOutput, bool) bool) error {
+	return m.proxies.DescribeDBProxiesPages(input, fn)
+}
+
+func (m *mockClusterInstanceDryRunDeleteConn) DescribeDBProxyTargetsPages(input *rds.DescribeDBProxyTargetsInput, fn func(*rds.DescribeDBProxyTargetsOutput, bool) bool) error {
+	return m.proxies.DescribeDBProxyTargetsPages(input, fn)
+}
+
+func TestDryRunDeleteClusterInstance(t *testing.T) {
+	instance := func(status string) *rds.DBInstance {
+		return &rds.DBInstance{
+			DBClusterIdentifier:  aws.String("test-cluster"),
+			DBInstanceIdentifier: aws.String("test-instance"),
+			DBInstanceStatus:     aws.String(status),
+		}
+	}
+	cluster := func(status string, members ...*rds.DBClusterMember) *rds.DBCluster {
+		return &rds.DBCluster{
+			DBClusterIdentifier: aws.String("test-cluster"),
+			DBClusterMembers:    members,
+			Status:              aws.String(status),
+		}
+	}
+	writer := &rds.DBClusterMember{DBInstanceIdentifier: aws.String("test-instance"), IsClusterWriter: aws.Bool(true)}
+	reader := &rds.DBClusterMember{DBInstanceIdentifier: aws.String("test-reader"), IsClusterWriter: aws.Bool(false)}
+	proxyTarget := &rds.DBProxyTarget{RdsResourceId: aws.String("test-instance"), Type: aws.String(rds.TargetTypeRdsInstance)}
+
+	testCases := []struct {
+		Description   string
+		Options       tfrds.ClusterInstanceDeleteOptions
+		DBInstances   []*rds.DBInstance
+		DBClusters    []*rds.DBCluster
+		ProxyTargets  map[string][]*rds.DBProxyTarget
+		Expected      tfrds.ClusterInstanceDeleteDryRun
+		ExpectedError bool
+	}{
+		{
+			Description: "not found",
+			Options:     tfrds.ClusterInstanceDeleteOptions{ID: "test-instance", DBClusterID: "test-cluster", Engine: tfrds.EngineAuroraMySQL},
+			Expected:    tfrds.ClusterInstanceDeleteDryRun{InstanceNotFound: true},
+		},
+		{
+			Description: "available",
+			Options:     tfrds.ClusterInstanceDeleteOptions{ID: "test-instance", DBClusterID: "test-cluster", Engine: tfrds.EngineAuroraMySQL},
+			DBInstances: []*rds.DBInstance{instance(tfrds.InstanceStatusAvailable)},
+			DBClusters:  []*rds.DBCluster{cluster(tfrds.ClusterStatusAvailable, writer)},
+			Expected:    tfrds.ClusterInstanceDeleteDryRun{WouldDelete: true},
+		},
+		{
+			Description: "already deleting",
+			Options:     tfrds.ClusterInstanceDeleteOptions{ID: "test-instance", DBClusterID: "test-cluster", Engine: tfrds.EngineAuroraMySQL},
+			DBInstances: []*rds.DBInstance{instance(tfrds.InstanceStatusDeleting)},
+			DBClusters:  []*rds.DBCluster{cluster(tfrds.ClusterStatusAvailable, writer)},
+			Expected:    tfrds.ClusterInstanceDeleteDryRun{InstanceDeleting: true},
+		},
+		{
+			Description: "cluster deleting",
+			Options:     tfrds.ClusterInstanceDeleteOptions{ID: "test-instance", DBClusterID: "test-cluster", Engine: tfrds.EngineAuroraMySQL},
+			DBInstances: []*rds.DBInstance{instance(tfrds.InstanceStatusAvailable)},
+			DBClusters:  []*rds.DBCluster{cluster(tfrds.ClusterStatusDeleting, writer)},
+			Expected:    tfrds.ClusterInstanceDeleteDryRun{ClusterDeleting: true, WouldDelete: true},
+		},
+		{
+			Description: "final snapshot",
+			Options:     tfrds.ClusterInstanceDeleteOptions{ID: "test-instance", DBClusterID: "test-cluster", Engine: tfrds.EngineMySQL, FinalSnapshotIdentifier: "test-snapshot"},
+			DBInstances: []*rds.DBInstance{instance(tfrds.InstanceStatusAvailable)},
+			DBClusters:  []*rds.DBCluster{cluster(tfrds.ClusterStatusAvailable, writer)},
+			Expected:    tfrds.ClusterInstanceDeleteDryRun{FinalSnapshotIdentifier: "test-snapshot", WouldDelete: true},
+		},
+		{
+			Description:   "final snapshot identifier missing",
+			Options:       tfrds.ClusterInstanceDeleteOptions{ID: "test-instance", DBClusterID: "test-cluster", Engine: tfrds.EngineMySQL},
+			DBInstances:   []*rds.DBInstance{instance(tfrds.InstanceStatusAvailable)},
+			DBClusters:    []*rds.DBCluster{cluster(tfrds.ClusterStatusAvailable, writer)},
+			ExpectedError: true,
+		},
+		{
+			Description:   "writer with readers",
+			Options:       tfrds.ClusterInstanceDeleteOptions{ID: "test-instance", DBClusterID: "test-cluster", Engine: tfrds.EngineAuroraMySQL, PreventWriterDeleteWithReaders: true},
+			DBInstances:   []*rds.DBInstance{instance(tfrds.InstanceStatusAvailable)},
+			DBClusters:    []*rds.DBCluster{cluster(tfrds.ClusterStatusAvailable, writer, reader)},
+			ExpectedError: true,
+		},
+		{
+			Description:  "proxy target warning",
+			Options:      tfrds.ClusterInstanceDeleteOptions{ID: "test-instance", DBClusterID: "test-cluster", Engine: tfrds.EngineAuroraMySQL, ProxyTargetDeleteCheck: tfrds.ProxyTargetDeleteCheckWarn},
+			DBInstances:  []*rds.DBInstance{instance(tfrds.InstanceStatusAvailable)},
+			DBClusters:   []*rds.DBCluster{cluster(tfrds.ClusterStatusAvailable, writer)},
+			ProxyTargets: map[string][]*rds.DBProxyTarget{"test-proxy": {proxyTarget}},
+			Expected: tfrds.ClusterInstanceDeleteDryRun{
+				Warnings:    []string{"RDS Cluster Instance (test-instance) is a target of RDS DB Proxies (test-proxy), deleting it can break their routing"},
+				WouldDelete: true,
+			},
+		},
+		{
+			Description:   "proxy target error",
+			Options:       tfrds.ClusterInstanceDeleteOptions{ID: "test-instance", DBClusterID: "test-cluster", Engine: tfrds.EngineAuroraMySQL, ProxyTargetDeleteCheck: tfrds.ProxyTargetDeleteCheckError},
+			DBInstances:   []*rds.DBInstance{instance(tfrds.InstanceStatusAvailable)},
+			DBClusters:    []*rds.DBCluster{cluster(tfrds.ClusterStatusAvailable, writer)},
+			ProxyTargets:  map[string][]*rds.DBProxyTarget{"test-proxy": {proxyTarget}},
+			ExpectedError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Description, func(t *testing.T) {
+			conn := &mockClusterInstanceDryRunDeleteConn{
+				dbClusters: testCase.DBClusters,
+				instances:  &mockClusterInstancesConn{instances: testCase.DBInstances},
+				proxies:    &mockClusterInstanceProxiesConn{targets: testCase.ProxyTargets},
+			}
+
+			got, err := tfrds.DryRunDeleteClusterInstance(conn, &testCase.Options)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if conn.deleteCalls != 0 {
+				t.Errorf("got %d DeleteDBInstance calls, expected 0", conn.deleteCalls)
+			}
+
+			if testCase.ExpectedError {
+				if got.Error == nil {
+					t.Fatal("expected Error, got none")
+				}
+
+				if got.WouldDelete {
+					t.Error("expected WouldDelete to be false")
+				}
+
+				return
+			}
+
+			if !reflect.DeepEqual(*got, testCase.Expected) {
+				t.Errorf("got %#v, expected %#v", *got, testCase.Expected)
+			}
+		})
+	}
+}
+
+func TestClusterInstanceError(t *testing.T) {
+	awsErr := awserr.New(rds.ErrCodeInvalidDBInstanceStateFault, "DB instance is not in an available state", nil)
+	err := fmt.Errorf("wrapped: %w", tfrds.NewClusterInstanceError(tfrds.ClusterInstanceOperationUpdate, "test-instance", fmt.Errorf("error modifying RDS Cluster Instance (test-instance): %w", awsErr)))
+
+	var clusterInstanceErr *tfrds.ClusterInstanceError
+	if !errors.As(err, &clusterInstanceErr) {
+		t.Fatalf("expected errors.As to find a ClusterInstanceError in: %s", err)
+	}
+
+	if got, expected := clusterInstanceErr.Operation, tfrds.ClusterInstanceOperationUpdate; got != expected {
+		t.Errorf("got Operation %q, expected %q", got, expected)
+	}
+
+	if got, expected := clusterInstanceErr.InstanceID, "test-instance"; got != expected {
+		t.Errorf("got InstanceID %q, expected %q", got, expected)
+	}
+
+	if got, expected := clusterInstanceErr.Error(), "error modifying RDS Cluster Instance (test-instance): InvalidDBInstanceState: DB instance is not in an available state"; got != expected {
+		t.Errorf("got message %q, expected %q", got, expected)
+	}
+
+	if !tfawserr.ErrCodeEquals(err, rds.ErrCodeInvalidDBInstanceStateFault) {
+		t.Errorf("expected the AWS error to be unwrapped from: %s", err)
+	}
+}
+
+func TestIsClusterInstancePrimaryDeleteError(t *testing.T) {
+	testCases := []struct {
+		Description string
+		Err         error
+		Expected    bool
+	}{
+		{
+			Description: "nil",
+			Expected:    false,
+		},
+		{
+			Description: "primary instance",
+			Err:         awserr.New(rds.ErrCodeInvalidDBClusterStateFault, "Cannot delete the primary instance of the DB cluster, fail over the DB cluster first", nil),
+			Expected:    true,
+		},
+		{
+			Description: "replica cluster",
+			Err:         awserr.New(rds.ErrCodeInvalidDBClusterStateFault, "Delete the replica cluster before deleting the DB instance", nil),
+			Expected:    false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Description, func(t *testing.T) {
+			if got := tfrds.IsClusterInstancePrimaryDeleteError(testCase.Err); got != testCase.Expected {
+				t.Errorf("got %t, expected %t", got, testCase.Expected)
+			}
+		})
+	}
+}
+
+type mockClusterInstanceTagsConn struct {
+	rdsiface.RDSAPI
+
+	tags      []*rds.Tag
+	throttles int
+	calls     int
+}
+
+func (m *mockClusterInstanceTagsConn) ListTagsForResourceWithContext(_ aws.Context, _ *rds.ListTagsForResourceInput, _ ...request.Option) (*rds.ListTagsForResourceOutput, error) {
+	m.calls++
+
+	if m.calls <= m.throttles {
+		return nil, awserr.New("Throttling", "Rate exceeded", nil)
+	}
+
+	return &rds.ListTagsForResourceOutput{TagList: m.tags}, nil
+}
+
+func TestClusterInstanceTags(t *testing.T) {
+	testCases := []struct {
+		Description   string
+		TagList       []*rds.Tag
+		Throttles     int
+		ExpectedTags  map[string]string
+		ExpectedCalls int
+	}{
+		{
+			Description:   "describe tags",
+			TagList:       []*rds.Tag{{Key: aws.String("Name"), Value: aws.String("describe")}},
+			ExpectedTags:  map[string]string{"Name": "describe"},
+			ExpectedCalls: 0,
+		},
+		{
+			Description:   "describe no tags",
+			TagList:       []*rds.Tag{},
+			ExpectedTags:  map[string]string{},
+			ExpectedCalls: 0,
+		},
+		{
+			Description:   "describe omits tags",
+			ExpectedTags:  map[string]string{"Name": "list"},
+			ExpectedCalls: 1,
+		},
+		{
+			Description:   "describe omits tags throttled",
+			Throttles:     2,
+			ExpectedTags:  map[string]string{"Name": "list"},
+			ExpectedCalls: 3,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Description, func(t *testing.T) {
+			conn := &mockClusterInstanceTagsConn{
+				tags:      []*rds.Tag{{Key: aws.String("Name"), Value: aws.String("list")}},
+				throttles: testCase.Throttles,
+			}
+			dbInstance := &rds.DBInstance{
+				DBInstanceArn: aws.String("arn:aws:rds:us-west-2:123456789012:db:test-instance"),
+				TagList:       testCase.TagList,
+			}
+
+			tags, err := tfrds.ClusterInstanceTags(conn, dbInstance)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if got := tags.Map(); !reflect.DeepEqual(got, testCase.ExpectedTags) {
+				t.Errorf("got tags %v, expected %v", got, testCase.ExpectedTags)
+			}
+
+			if conn.calls != testCase.ExpectedCalls {
+				t.Errorf("got %d ListTagsForResource calls, expected %d", conn.calls, testCase.ExpectedCalls)
+			}
+		})
+	}
+}
+
+type mockClusterInstanceParameterGroupsConn struct {
+	rdsiface.RDSAPI
+
+	families map[string]string
+}
+
+func (m *mockClusterInstanceParameterGroupsConn) DescribeDBParameterGroups(input *rds.DescribeDBParameterGroupsInput) (*rds.DescribeDBParameterGroupsOutput, error) {
+	family, ok := m.families[aws.StringValue(input.DBParameterGroupName)]
+
+	if !ok {
+		return nil, awserr.New(rds.ErrCodeDBParameterGroupNotFoundFault, "not found", nil)
+	}
+
+	return &rds.DescribeDBParameterGroupsOutput{
+		DBParameterGroups: []*rds.DBParameterGroup{{
+			DBParameterGroupFamily: aws.String(family),
+			DBParameterGroupName:   input.DBParameterGroupName,
+		}},
+	}, nil
+}
+
+func TestValidateClusterInstanceDBParameterGroup(t *testing.T) {
+	conn := &mockClusterInstanceParameterGroupsConn{
+		families: map[string]string{
+			"aurora-mysql57":      "aurora-mysql5.7",
+			"aurora-postgresql13": "aurora-postgresql13",
+			"aurora-postgresql14": "aurora-postgresql14",
+		},
+	}
+
+	testCases := []struct {
+		Description   string
+		Name          string
+		EngineVersion string
+		ExpectedError bool
+	}{
+		{
+			Description: "no name",
+		},
+		{
+			Description:   "compatible family",
+			Name:          "aurora-postgresql13",
+			EngineVersion: "13.7",
+		},
+		{
+			Description:   "incompatible family version",
+			Name:          "aurora-postgresql14",
+			EngineVersion: "13.7",
+			ExpectedError: true,
+		},
+		{
+			Description:   "incompatible family engine",
+			Name:          "aurora-mysql57",
+			EngineVersion: "13.7",
+			ExpectedError: true,
+		},
+		{
+			Description:   "not found",
+			Name:          "missing",
+			EngineVersion: "13.7",
+			ExpectedError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Description, func(t *testing.T) {
+			err := tfrds.ValidateClusterInstanceDBParameterGroup(conn, testCase.Name, "aurora-postgresql", testCase.EngineVersion)
+
+			if testCase.ExpectedError && err == nil {
+				t.Fatal("expected error, got none")
+			}
+
+			if !testCase.ExpectedError && err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+		})
+	}
+}
+
+type mockClusterInstanceClustersConn struct {
+	rdsiface.RDSAPI
+
+	mu     sync.Mutex
+	calls  int
+	writer string
+}
+
+func (m *mockClusterInstanceClustersConn) DescribeDBClusters(input *rds.DescribeDBClustersInput) (*rds.DescribeDBClustersOutput, error) {
+	m.mu.Lock()
+	m.calls++
+	writer := m.writer
+	m.mu.Unlock()
+
+	// Give concurrent reads time to find the describe in flight.
+	time.Sleep(10 * time.Millisecond)
+
+	dbCluster := &rds.DBCluster{DBClusterIdentifier: input.DBClusterIdentifier}
+
+	for _, id := range []string{"test-instance-1", "test-instance-2"} {
+		dbCluster.DBClusterMembers = append(dbCluster.DBClusterMembers, &rds.DBClusterMember{
+			DBInstanceIdentifier: aws.String(id),
+			IsClusterWriter:      aws.Bool(id == writer),
+		})
+	}
+
+	return &rds.DescribeDBClustersOutput{
+		DBClusters: []*rds.DBCluster{dbCluster},
+	}, nil
+}
+
+func (m *mockClusterInstanceClustersConn) failover(writer string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.writer = writer
+}
+
+func TestClusterInstanceDBClusterCache(t *testing.T) {
+	t.Run("shared describe", func(t *testing.T) {
+		conn := &mockClusterInstanceClustersConn{}
+		cache := tfrds.NewClusterInstanceDBClusterCache()
+
+		var wg sync.WaitGroup
+		for i := 0; i < 15; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				dbCluster, err := cache.Get(conn, "test-cluster")
+
+				if err != nil {
+					t.Errorf("unexpected error: %s", err)
+					return
+				}
+
+				if got, expected := aws.StringValue(dbCluster.DBClusterIdentifier), "test-cluster"; got != expected {
+					t.Errorf("got DBClusterIdentifier %q, expected %q", got, expected)
+				}
+			}()
+		}
+		wg.Wait()
+
+		if conn.calls != 1 {
+			t.Errorf("got %d DescribeDBClusters calls, expected 1", conn.calls)
+		}
+
+		if _, err := cache.Get(conn, "other-cluster"); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if _, err := cache.Get(&mockClusterInstanceClustersConn{}, "test-cluster"); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if conn.calls != 2 {
+			t.Errorf("got %d DescribeDBClusters calls, expected 2", conn.calls)
+		}
+	})
+
+	t.Run("not kept after describe", func(t *testing.T) {
+		conn := &mockClusterInstanceClustersConn{}
+		cache := tfrds.NewClusterInstanceDBClusterCache()
+
+		for i := 0; i < 2; i++ {
+			if _, err := cache.Get(conn, "test-cluster"); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+		}
+
+		if conn.calls != 2 {
+			t.Errorf("got %d DescribeDBClusters calls, expected 2", conn.calls)
+		}
+
+		if got, expected := cache.Len(), 0; got != expected {
+			t.Errorf("got %d DB clusters being described, expected %d", got, expected)
+		}
+	})
+
+	t.Run("writer after failover", func(t *testing.T) {
+		conn := &mockClusterInstanceClustersConn{writer: "test-instance-1"}
+		cache := tfrds.NewClusterInstanceDBClusterCache()
+
+		for _, writer := range []string{"test-instance-1", "test-instance-2"} {
+			conn.failover(writer)
+
+			dbCluster, err := cache.Get(conn, "test-cluster")
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			for _, member := range dbCluster.DBClusterMembers {
+				id := aws.StringValue(member.DBInstanceIdentifier)
+
+				if got, expected := aws.BoolValue(member.IsClusterWriter), id == writer; got != expected {
+					t.Errorf("after failover to %s, got writer %t for %s, expected %t", writer, got, id, expected)
+				}
+			}
+		}
+	})
+}
+
+type mockClusterInstanceEngineVersionsConn struct {
+	rdsiface.RDSAPI
+
+	upgradeTargets map[string][]string
+}
+
+func (m *mockClusterInstanceEngineVersionsConn) DescribeDBEngineVersionsPages(input *rds.DescribeDBEngineVersionsInput, fn func(*rds.DescribeDBEngineVersionsOutput, bool) bool) error {
+	output := &rds.DescribeDBEngineVersionsOutput{}
+
+	if targets, ok := m.upgradeTargets[aws.StringValue(input.EngineVersion)]; ok {
+		engineVersion := &rds.DBEngineVersion{
+			Engine:        input.Engine,
+			EngineVersion: input.EngineVersion,
+		}
+
+		for _, target := range targets {
+			engineVersion.ValidUpgradeTarget = append(engineVersion.ValidUpgradeTarget, &rds.UpgradeTarget{
+				Engine:        input.Engine,
+				EngineVersion: aws.String(target),
+			})
+		}
+
+		output.DBEngineVersions = []*rds.DBEngineVersion{engineVersion}
+	}
+
+	fn(output, true)
+
+	return nil
+}
+
+func TestClusterInstanceEngineVersionUpgradeAvailable(t *testing.T) {
+	conn := &mockClusterInstanceEngineVersionsConn{
+		upgradeTargets: map[string][]string{
+			"13.6": {"13.7", "14.3"},
+			"14.3": {},
+		},
+	}
+
+	testCases := []struct {
+		Description   string
+		EngineVersion string
+		Expected      bool
+	}{
+		{
+			Description:   "upgrade targets",
+			EngineVersion: "13.6",
+			Expected:      true,
+		},
+		{
+			Description:   "no upgrade targets",
+			EngineVersion: "14.3",
+			Expected:      false,
+		},
+		{
+			Description:   "unknown version",
+			EngineVersion: "12.1",
+			Expected:      false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Description, func(t *testing.T) {
+			got, err := tfrds.ClusterInstanceEngineVersionUpgradeAvailable(conn, "aurora-postgresql", testCase.EngineVersion)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if got != testCase.Expected {
+				t.Errorf("got %t, expected %t", got, testCase.Expected)
+			}
+		})
+	}
+}
+
+func TestClusterInstanceCreateError(t *testing.T) {
+	testCases := []struct {
+		Description     string
+		Err             error
+		Input           *rds.CreateDBInstanceInput
+		ExpectedMessage string
+	}{
+		{
+			Description: "performance insights",
+			Err:         awserr.New("InvalidParameterCombination", "Performance Insights not supported for this configuration.", nil),
+			Input: &rds.CreateDBInstanceInput{
+				Engine:                    aws.String("aurora-mysql"),
+				EnablePerformanceInsights: aws.Bool(true),
+				MonitoringInterval:        aws.Int64(60),
+			},
+			ExpectedMessage: "the combination of engine (aurora-mysql) and configuration isn't supported, check performance_insights_enabled: ",
+		},
+		{
+			Description: "enhanced monitoring",
+			Err:         awserr.New("InvalidParameterCombination", "Enhanced Monitoring is not supported for the engine.", nil),
+			Input: &rds.CreateDBInstanceInput{
+				Engine:                    aws.String("aurora-postgresql"),
+				EnablePerformanceInsights: aws.Bool(false),
+				MonitoringInterval:        aws.Int64(30),
+			},
+			ExpectedMessage: "check monitoring_interval: ",
+		},
+		{
+			Description: "not mentioned",
+			Err:         awserr.New("InvalidParameterCombination", "The engine does not support the requested configuration.", nil),
+			Input: &rds.CreateDBInstanceInput{
+				Engine:                             aws.String("aurora-postgresql"),
+				BackupTarget:                       aws.String("outposts"),
+				PerformanceInsightsRetentionPeriod: aws.Int64(731),
+			},
+			ExpectedMessage: "check backup_target, performance_insights_retention_period: ",
+		},
+		{
+			Description: "nothing set",
+			Err:         awserr.New("InvalidParameterCombination", "The engine does not support the requested configuration.", nil),
+			Input: &rds.CreateDBInstanceInput{
+				Engine: aws.String("aurora-postgresql"),
+			},
+		},
+		{
+			Description: "other error",
+			Err:         awserr.New(rds.ErrCodeDBClusterNotFoundFault, "DBCluster not found", nil),
+			Input: &rds.CreateDBInstanceInput{
+				Engine:                    aws.String("aurora-postgresql"),
+				EnablePerformanceInsights: aws.Bool(true),
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Description, func(t *testing.T) {
+			err := tfrds.ClusterInstanceCreateError(testCase.Err, testCase.Input)
+
+			if !errors.Is(err, testCase.Err) {
+				t.Errorf("expected the error to wrap %q, got: %s", testCase.Err, err)
+			}
+
+			if testCase.ExpectedMessage == "" {
+				if err != testCase.Err {
+					t.Errorf("expected the error to be unchanged, got: %s", err)
+				}
+
+				return
+			}
+
+			if !strings.Contains(err.Error(), testCase.ExpectedMessage) {
+				t.Errorf("expected the error to contain %q, got: %s", testCase.ExpectedMessage, err)
+			}
+		})
+	}
+}
+
+func TestClusterInstanceModifyError(t *testing.T) {
+	dependencyErr := awserr.New(rds.ErrCodeDBUpgradeDependencyFailureFault, "The DB instance can't be upgraded", nil)
+	otherErr := awserr.New(rds.ErrCodeInvalidDBInstanceStateFault, "DB instance is not in available state", nil)
+
+	testCases := []struct {
+		Description          string
+		Err                  error
+		DBParameterGroupName string
+		ExpectedMessage      string
+	}{
+		{
+			Description:          "upgrade dependency failure",
+			Err:                  dependencyErr,
+			DBParameterGroupName: "test-aurora-postgresql13",
+			ExpectedMessage:      "the DB parameter group (test-aurora-postgresql13) or the DB cluster's option group is likely not compatible",
+		},
+		{
+			Description:     "upgrade dependency failure default parameter group",
+			Err:             dependencyErr,
+			ExpectedMessage: "the DB parameter group or the DB cluster's option group is likely not compatible",
+		},
+		{
+			Description:          "other error",
+			Err:                  otherErr,
+			DBParameterGroupName: "test-aurora-postgresql13",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Description, func(t *testing.T) {
+			err := tfrds.ClusterInstanceModifyError(testCase.Err, testCase.DBParameterGroupName)
+
+			if !errors.Is(err, testCase.Err) {
+				t.Errorf("expected the error to wrap %q, got: %s", testCase.Err, err)
+			}
+
+			if testCase.ExpectedMessage == "" {
+				if err != testCase.Err {
+					t.Errorf("expected the error to be unchanged, got: %s", err)
+				}
+
+				return
+			}
+
+			if !strings.Contains(err.Error(), testCase.ExpectedMessage) {
+				t.Errorf("expected the error to contain %q, got: %s", testCase.ExpectedMessage, err)
+			}
+		})
+	}
+}
+
+type mockClusterInstanceDryRunCreateConn struct {
+	rdsiface.RDSAPI
+
+	dbCluster   *rds.DBCluster
+	options     []*rds.OrderableDBInstanceOption
+	createCalls int
+}
+
+func (m *mockClusterInstanceDryRunCreateConn) CreateDBInstance(input *rds.CreateDBInstanceInput) (*rds.CreateDBInstanceOutput, error) {
+	m.createCalls++
+
+	return &rds.CreateDBInstanceOutput{DBInstance: &rds.DBInstance{DBInstanceIdentifier: input.DBInstanceIdentifier}}, nil
+}
+
+func (m *mockClusterInstanceDryRunCreateConn) DescribeDBClusters(input *rds.DescribeDBClustersInput) (*rds.DescribeDBClustersOutput, error) {
+	if m.dbCluster == nil || aws.StringValue(m.dbCluster.DBClusterIdentifier) != aws.StringValue(input.DBClusterIdentifier) {
+		return nil, awserr.New(rds.ErrCodeDBClusterNotFoundFault, "not found", nil)
+	}
+
+	return &rds.DescribeDBClustersOutput{DBClusters: []*rds.DBCluster{m.dbCluster}}, nil
+}
+
+func (m *mockClusterInstanceDryRunCreateConn) DescribeOrderableDBInstanceOptionsPages(input *rds.DescribeOrderableDBInstanceOptionsInput, fn func(*rds.DescribeOrderableDBInstanceOptionsOutput, bool) bool) error {
+	fn(&rds.DescribeOrderableDBInstanceOptionsOutput{OrderableDBInstanceOptions: m.options}, true)
+
+	return nil
+}
+
+func TestDryRunCreateClusterInstance(t *testing.T) {
+	options := []*rds.OrderableDBInstanceOption{
+		{
+			AvailabilityZones: []*rds.AvailabilityZone{{Name: aws.String("us-west-2a")}, {Name: aws.String("us-west-2b")}},
+			DBInstanceClass:   aws.String("db.r6g.large"),
+			Engine:            aws.String("aurora-postgresql"),
+		},
+		{
+			AvailabilityZones: []*rds.AvailabilityZone{{Name: aws.String("us-west-2a")}},
+			DBInstanceClass:   aws.String("db.r5.large"),
+			Engine:            aws.String("aurora-postgresql"),
+		},
+	}
+
+	testCases := []struct {
+		Description   string
+		EngineMode    string
+		ClusterID     string
+		InstanceClass string
+		AZ            string
+		ExpectedError bool
+	}{
+		{
+			Description:   "provisionable",
+			InstanceClass: "db.r6g.large",
+		},
+		{
+			Description:   "provisionable in availability zone",
+			InstanceClass: "db.r6g.large",
+			AZ:            "us-west-2b",
+		},
+		{
+			Description:   "serverless",
+			InstanceClass: "db.serverless",
+		},
+		{
+			Description:   "instance class not orderable",
+			InstanceClass: "db.x2g.large",
+			ExpectedError: true,
+		},
+		{
+			Description:   "instance class not in availability zone",
+			InstanceClass: "db.r5.large",
+			AZ:            "us-west-2b",
+			ExpectedError: true,
+		},
+		{
+			Description:   "cluster not found",
+			ClusterID:     "missing-cluster",
+			InstanceClass: "db.r6g.large",
+			ExpectedError: true,
+		},
+		{
+			Description:   "serverless v1 cluster",
+			EngineMode:    "serverless",
+			InstanceClass: "db.r6g.large",
+			ExpectedError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Description, func(t *testing.T) {
+			engineMode := testCase.EngineMode
+			if engineMode == "" {
+				engineMode = "provisioned"
+			}
+			clusterID := testCase.ClusterID
+			if clusterID == "" {
+				clusterID = "test-cluster"
+			}
+
+			conn := &mockClusterInstanceDryRunCreateConn{
+				dbCluster: &rds.DBCluster{
+					DBClusterIdentifier: aws.String("test-cluster"),
+					EngineMode:          aws.String(engineMode),
+				},
+				options: options,
+			}
+			input := &rds.CreateDBInstanceInput{
+				DBClusterIdentifier:  aws.String(clusterID),
+				DBInstanceClass:      aws.String(testCase.InstanceClass),
+				DBInstanceIdentifier: aws.String("test-instance"),
+				Engine:               aws.String("aurora-postgresql"),
+			}
+			if testCase.AZ != "" {
+				input.AvailabilityZone = aws.String(testCase.AZ)
+			}
+
+			err := tfrds.DryRunCreateClusterInstance(conn, input)
+
+			if testCase.ExpectedError && err == nil {
+				t.Error("expected error, got none")
+			}
+
+			if !testCase.ExpectedError && err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+
+			if conn.createCalls != 0 {
+				t.Errorf("got %d CreateDBInstance calls, expected none", conn.createCalls)
+			}
+		})
+	}
+}
+
+func TestRunClusterInstanceUpdatesConcurrently(t *testing.T) {
+	t.Run("concurrent", func(t *testing.T) {
+		// Each update only finishes once the other has started, which deadlocks unless they run concurrently.
+		started1, started2 := make(chan struct{}), make(chan struct{})
+		done := make(chan error)
+
+		go func() {
+			done <- tfrds.RunClusterInstanceUpdatesConcurrently(
+				func() error {
+					close(started1)
+					<-started2
+					return nil
+				},
+				nil,
+				func() error {
+					close(started2)
+					<-started1
+					return nil
+				},
+			)
+		}()
+
+		select {
+		case err := <-done:
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+		case <-time.After(10 * time.Second):
+			t.Fatal("updates didn't run concurrently")
+		}
+	})
+
+	t.Run("single error", func(t *testing.T) {
+		tagsErr := errors.New("error updating tags")
+
+		err := tfrds.RunClusterInstanceUpdatesConcurrently(
+			func() error { return nil },
+			func() error { return tagsErr },
+		)
+
+		if err != tagsErr {
+			t.Errorf("got error %v, expected %v", err, tagsErr)
+		}
+	})
+
+	t.Run("multiple errors", func(t *testing.T) {
+		modifyErr, tagsErr := errors.New("error modifying"), errors.New("error updating tags")
+
+		err := tfrds.RunClusterInstanceUpdatesConcurrently(
+			func() error { return modifyErr },
+			func() error { return tagsErr },
+		)
+
+		if !errors.Is(err, modifyErr) || !errors.Is(err, tagsErr) {
+			t.Errorf("expected the error to include both errors, got: %v", err)
+		}
+	})
+}
+
+type mockClusterInstanceDeleteConn struct {
+	rdsiface.RDSAPI
+
+	input *rds.DeleteDBInstanceInput
+}
+
+func (m *mockClusterInstanceDeleteConn) DeleteDBInstance(input *rds.DeleteDBInstanceInput) (*rds.DeleteDBInstanceOutput, error) {
+	m.input = input
+
+	if !aws.BoolValue(input.SkipFinalSnapshot) {
+		return nil, awserr.New(rds.ErrCodeSnapshotQuotaExceededFault, "Cannot create more than 100 manual snapshots", nil)
+	}
+
+	return &rds.DeleteDBInstanceOutput{}, nil
+}
+
+func TestDeleteClusterInstanceWithoutFinalSnapshot(t *testing.T) {
+	conn := &mockClusterInstanceDeleteConn{}
+	input := &rds.DeleteDBInstanceInput{
+		DBInstanceIdentifier:      aws.String("test-instance"),
+		FinalDBSnapshotIdentifier: aws.String("test-instance-final"),
+		SkipFinalSnapshot:         aws.Bool(false),
+	}
+
+	if _, err := conn.DeleteDBInstance(input); !tfawserr.ErrCodeEquals(err, rds.ErrCodeSnapshotQuotaExceededFault) {
+		t.Fatalf("expected %s error, got: %v", rds.ErrCodeSnapshotQuotaExceededFault, err)
+	}
+
+	if err := tfrds.DeleteClusterInstanceWithoutFinalSnapshot(conn, input); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if got, expected := aws.StringValue(conn.input.DBInstanceIdentifier), "test-instance"; got != expected {
+		t.Errorf("got DBInstanceIdentifier %q, expected %q", got, expected)
+	}
+
+	if !aws.BoolValue(conn.input.SkipFinalSnapshot) {
+		t.Error("expected SkipFinalSnapshot to be true")
+	}
+
+	if conn.input.FinalDBSnapshotIdentifier != nil {
+		t.Errorf("expected no FinalDBSnapshotIdentifier, got %q", aws.StringValue(conn.input.FinalDBSnapshotIdentifier))
+	}
+
+	if got, expected := aws.StringValue(input.FinalDBSnapshotIdentifier), "test-instance-final"; got != expected {
+		t.Errorf("expected the original input to be unchanged, got FinalDBSnapshotIdentifier %q", got)
+	}
+}
+
+func TestClusterInstanceIsWriterWithReaders(t *testing.T) {
+	testCases := []struct {
+		Description string
+		ID          string
+		DBCluster   *rds.DBCluster
+		Expected    bool
+	}{
+		{
+			Description: "writer with readers",
+			ID:          "writer",
+			DBCluster: &rds.DBCluster{
+				DBClusterMembers: []*rds.DBClusterMember{
+					{DBInstanceIdentifier: aws.String("writer"), IsClusterWriter: aws.Bool(true)},
+					{DBInstanceIdentifier: aws.String("reader"), IsClusterWriter: aws.Bool(false)},
+				},
+			},
+			Expected: true,
+		},
+		{
+			Description: "writer only",
+			ID:          "writer",
+			DBCluster: &rds.DBCluster{
+				DBClusterMembers: []*rds.DBClusterMember{
+					{DBInstanceIdentifier: aws.String("writer"), IsClusterWriter: aws.Bool(true)},
+				},
+			},
+			Expected: false,
+		},
+		{
+			Description: "reader",
+			ID:          "reader",
+			DBCluster: &rds.DBCluster{
+				DBClusterMembers: []*rds.DBClusterMember{
+					{DBInstanceIdentifier: aws.String("writer"), IsClusterWriter: aws.Bool(true)},
+					{DBInstanceIdentifier: aws.String("reader"), IsClusterWriter: aws.Bool(false)},
+				},
+			},
+			Expected: false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Description, func(t *testing.T) {
+			if got := tfrds.ClusterInstanceIsWriterWithReaders(testCase.ID, testCase.DBCluster); got != testCase.Expected {
+				t.Errorf("got %t, expected %t", got, testCase.Expected)
+			}
+		})
+	}
+}
+
+func TestClusterInstanceIsLastOfProtectedCluster(t *testing.T) {
+	testCases := []struct {
+		Description string
+		ID          string
+		DBCluster   *rds.DBCluster
+		Expected    bool
+	}{
+		{
+			Description: "last instance protected",
+			ID:          "writer",
+			DBCluster: &rds.DBCluster{
+				DBClusterMembers: []*rds.DBClusterMember{
+					{DBInstanceIdentifier: aws.String("writer"), IsClusterWriter: aws.Bool(true)},
+				},
+				DeletionProtection: aws.Bool(true),
+			},
+			Expected: true,
+		},
+		{
+			Description: "last instance unprotected",
+			ID:          "writer",
+			DBCluster: &rds.DBCluster{
+				DBClusterMembers: []*rds.DBClusterMember{
+					{DBInstanceIdentifier: aws.String("writer"), IsClusterWriter: aws.Bool(true)},
+				},
+				DeletionProtection: aws.Bool(false),
+			},
+			Expected: false,
+		},
+		{
+			Description: "other instances protected",
+			ID:          "reader",
+			DBCluster: &rds.DBCluster{
+				DBClusterMembers: []*rds.DBClusterMember{
+					{DBInstanceIdentifier: aws.String("writer"), IsClusterWriter: aws.Bool(true)},
+					{DBInstanceIdentifier: aws.String("reader"), IsClusterWriter: aws.Bool(false)},
+				},
+				DeletionProtection: aws.Bool(true),
+			},
+			Expected: false,
+		},
+		{
+			Description: "not a member protected",
+			ID:          "reader",
+			DBCluster: &rds.DBCluster{
+				DBClusterMembers: []*rds.DBClusterMember{
+					{DBInstanceIdentifier: aws.String("writer"), IsClusterWriter: aws.Bool(true)},
+				},
+				DeletionProtection: aws.Bool(true),
+			},
+			Expected: false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Description, func(t *testing.T) {
+			if got := tfrds.ClusterInstanceIsLastOfProtectedCluster(testCase.ID, testCase.DBCluster); got != testCase.Expected {
+				t.Errorf("got %t, expected %t", got, testCase.Expected)
+			}
+		})
+	}
+}
+
+func TestRetryClusterInstanceIAMPropagation(t *testing.T) {
+	iamErr := awserr.New("InvalidParameterValue", "IAM role ARN value is invalid or does not include the required permissions for: ENHANCED_MONITORING", nil)
+
+	t.Run("success after retries", func(t *testing.T) {
+		var calls int
+
+		err := tfrds.RetryClusterInstanceIAMPropagation(1*time.Minute, func() error {
+			calls++
+			if calls < 2 {
+				return iamErr
+			}
+			return nil
+		})
+
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if calls != 2 {
+			t.Errorf("got %d calls, expected 2", calls)
+		}
+	})
+
+	t.Run("non-IAM error", func(t *testing.T) {
+		var calls int
+
+		err := tfrds.RetryClusterInstanceIAMPropagation(1*time.Minute, func() error {
+			calls++
+			return awserr.New(rds.ErrCodeInvalidDBClusterStateFault, "test", nil)
+		})
+
+		if !tfawserr.ErrCodeEquals(err, rds.ErrCodeInvalidDBClusterStateFault) {
+			t.Fatalf("got error %v, expected %s", err, rds.ErrCodeInvalidDBClusterStateFault)
+		}
+
+		if calls != 1 {
+			t.Errorf("got %d calls, expected 1", calls)
+		}
+	})
+
+	t.Run("configured timeout honored", func(t *testing.T) {
+		timeout := 2 * time.Second
+		start := time.Now()
+
+		err := tfrds.RetryClusterInstanceIAMPropagation(timeout, func() error {
+			return iamErr
+		})
+
+		if !tfawserr.ErrCodeEquals(err, "InvalidParameterValue") {
+			t.Fatalf("got error %v, expected InvalidParameterValue", err)
+		}
+
+		if elapsed := time.Since(start); elapsed < timeout || elapsed > timeout+10*time.Second {
+			t.Errorf("got elapsed %s, expected about %s", elapsed, timeout)
+		}
+	})
+
+	t.Run("role ready", func(t *testing.T) {
+		var calls int
+
+		err := tfrds.RetryClusterInstanceIAMPropagation(0, func() error {
+			calls++
+			return iamErr
+		})
+
+		if !tfawserr.ErrCodeEquals(err, "InvalidParameterValue") {
+			t.Fatalf("got error %v, expected InvalidParameterValue", err)
+		}
+
+		if calls != 1 {
+			t.Errorf("got %d calls, expected 1", calls)
+		}
+	})
+}
+
+type mockClusterInstanceSnapshotsConn struct {
+	rdsiface.RDSAPI
+
+	snapshots         []*rds.DBSnapshot
+	describeInput     *rds.DescribeDBSnapshotsInput
+	addTagsToResource map[string]map[string]string
+}
+
+func (m *mockClusterInstanceSnapshotsConn) DescribeDBSnapshotsPages(input *rds.DescribeDBSnapshotsInput, fn func(*rds.DescribeDBSnapshotsOutput, bool) bool) error {
+	m.describeInput = input
+	fn(&rds.DescribeDBSnapshotsOutput{DBSnapshots: m.snapshots}, true)
+
+	return nil
+}
+
+func (m *mockClusterInstanceSnapshotsConn) AddTagsToResourceWithContext(_ aws.Context, input *rds.AddTagsToResourceInput, _ ...request.Option) (*rds.AddTagsToResourceOutput, error) {
+	if m.addTagsToResource == nil {
+		m.addTagsToResource = make(map[string]map[string]string)
+	}
+
+	m.addTagsToResource[aws.StringValue(input.ResourceName)] = tfrds.KeyValueTags(input.Tags).Map()
+
+	return &rds.AddTagsToResourceOutput{}, nil
+}
+
+func TestTagClusterInstanceSnapshots(t *testing.T) {
+	t.Run("automated snapshots", func(t *testing.T) {
+		conn := &mockClusterInstanceSnapshotsConn{
+			snapshots: []*rds.DBSnapshot{
+				{DBSnapshotArn: aws.String("arn:aws:rds:us-west-2:123456789012:snapshot:rds:test-instance-2022-07-01-00-00")},
+				{DBSnapshotArn: aws.String("arn:aws:rds:us-west-2:123456789012:snapshot:rds:test-instance-2022-07-02-00-00")},
+			},
+		}
+		tags := tftags.New(map[string]string{"CostCenter": "1234"})
+
+		if err := tfrds.TagClusterInstanceSnapshots(conn, "test-instance", tags); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if got, expected := aws.StringValue(conn.describeInput.DBInstanceIdentifier), "test-instance"; got != expected {
+			t.Errorf("got DBInstanceIdentifier %q, expected %q", got, expected)
+		}
+
+		if got, expected := aws.StringValue(conn.describeInput.SnapshotType), "automated"; got != expected {
+			t.Errorf("got SnapshotType %q, expected %q", got, expected)
+		}
+
+		expected := map[string]map[string]string{
+			"arn:aws:rds:us-west-2:123456789012:snapshot:rds:test-instance-2022-07-01-00-00": {"CostCenter": "1234"},
+			"arn:aws:rds:us-west-2:123456789012:snapshot:rds:test-instance-2022-07-02-00-00": {"CostCenter": "1234"},
+		}
+
+		if !reflect.DeepEqual(conn.addTagsToResource, expected) {
+			t.Errorf("got tagged snapshots %v, expected %v", conn.addTagsToResource, expected)
+		}
+	})
+
+	t.Run("no tags", func(t *testing.T) {
+		conn := &mockClusterInstanceSnapshotsConn{
+			snapshots: []*rds.DBSnapshot{
+				{DBSnapshotArn: aws.String("arn:aws:rds:us-west-2:123456789012:snapshot:rds:test-instance-2022-07-01-00-00")},
+			},
+		}
+
+		if err := tfrds.TagClusterInstanceSnapshots(conn, "test-instance", tftags.New(nil)); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if conn.describeInput != nil || len(conn.addTagsToResource) != 0 {
+			t.Errorf("expected no API calls, got DescribeDBSnapshots %v and AddTagsToResource %v", conn.describeInput, conn.addTagsToResource)
+		}
+	})
+}
+
+type mockClusterInstanceProxiesConn struct {
+	rdsiface.RDSAPI
+
+	targets map[string][]*rds.DBProxyTarget
+}
+
+func (m *mockClusterInstanceProxiesConn) DescribeDBProxiesPages(_ *rds.DescribeDBProxiesInput, fn func(*rds.DescribeDBProxiesOutput, bool) bool) error {
+	var proxyNames []string
+	for proxyName := range m.targets {
+		proxyNames = append(proxyNames, proxyName)
+	}
+	sort.Strings(proxyNames)
+
+	var proxies []*rds.DBProxy
+	for _, proxyName := range proxyNames {
+		proxies = append(proxies, &rds.DBProxy{DBProxyName: aws.String(proxyName)})
+	}
+
+	fn(&rds.DescribeDBProxiesOutput{DBProxies: proxies}, true)
+
+	return nil
+}
+
+func (m *mockClusterInstanceProxiesConn) DescribeDBProxyTargetsPages(input *rds.DescribeDBProxyTargetsInput, fn func(*rds.DescribeDBProxyTargetsOutput, bool) bool) error {
+	targets, ok := m.targets[aws.StringValue(input.DBProxyName)]
+
+	if !ok || targets == nil {
+		return awserr.New(rds.ErrCodeDBProxyNotFoundFault, "not found", nil)
+	}
+
+	fn(&rds.DescribeDBProxyTargetsOutput{Targets: targets}, true)
+
+	return nil
+}
+
+func TestFindDBProxyNamesByTargetDBInstanceID(t *testing.T) {
+	testCases := []struct {
+		Description string
+		Targets     map[string][]*rds.DBProxyTarget
+		Expected    []string
+	}{
+		{
+			Description: "no proxies",
+		},
+		{
+			Description: "not a target",
+			Targets: map[string][]*rds.DBProxyTarget{
+				"proxy1": {
+					{RdsResourceId: aws.String("test-cluster"), Type: aws.String(rds.TargetTypeTrackedCluster)},
+					{RdsResourceId: aws.String("other-instance"), Type: aws.String(rds.TargetTypeRdsInstance)},
+				},
+			},
+		},
+		{
+			Description: "target of some proxies",
+			Targets: map[string][]*rds.DBProxyTarget{
+				"proxy1": {
+					{RdsResourceId: aws.String("test-cluster"), Type: aws.String(rds.TargetTypeTrackedCluster)},
+					{RdsResourceId: aws.String("test-instance"), Type: aws.String(rds.TargetTypeRdsInstance)},
+				},
+				"proxy2": {
+					{RdsResourceId: aws.String("other-instance"), Type: aws.String(rds.TargetTypeRdsInstance)},
+				},
+				"proxy3": {
+					{RdsResourceId: aws.String("test-instance"), Type: aws.String(rds.TargetTypeRdsInstance)},
+				},
+			},
+			Expected: []string{"proxy1", "proxy3"},
+		},
+		{
+			Description: "proxy deleted concurrently",
+			Targets: map[string][]*rds.DBProxyTarget{
+				"proxy1": nil,
+				"proxy2": {
+					{RdsResourceId: aws.String("test-instance"), Type: aws.String(rds.TargetTypeRdsInstance)},
+				},
+			},
+			Expected: []string{"proxy2"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Description, func(t *testing.T) {
+			got, err := tfrds.FindDBProxyNamesByTargetDBInstanceID(&mockClusterInstanceProxiesConn{targets: testCase.Targets}, "test-instance")
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !reflect.DeepEqual(got, testCase.Expected) {
+				t.Errorf("got %#v, expected %#v", got, testCase.Expected)
+			}
+		})
+	}
+}
+
+type mockClusterInstancesConn struct {
+	rdsiface.RDSAPI
+
+	instances []*rds.DBInstance
+}
+
+func (m *mockClusterInstancesConn) DescribeDBInstancesPages(input *rds.DescribeDBInstancesInput, fn func(*rds.DescribeDBInstancesOutput, bool) bool) error {
+	for _, instance := range m.instances {
+		if aws.StringValue(instance.DBInstanceIdentifier) == aws.StringValue(input.DBInstanceIdentifier) {
+			fn(&rds.DescribeDBInstancesOutput{DBInstances: []*rds.DBInstance{instance}}, true)
+
+			return nil
+		}
+	}
+
+	return awserr.New(rds.ErrCodeDBInstanceNotFoundFault, "not found", nil)
+}
+
+type mockDBInstancesPagesConn struct {
+	rdsiface.RDSAPI
+
+	pages [][]*rds.DBInstance
+	input *rds.DescribeDBInstancesInput
+}
+
+func (m *mockDBInstancesPagesConn) DescribeDBInstancesPages(input *rds.DescribeDBInstancesInput, fn func(*rds.DescribeDBInstancesOutput, bool) bool) error {
+	m.input = input
+
+	for i, page := range m.pages {
+		if !fn(&rds.DescribeDBInstancesOutput{DBInstances: page}, i == len(m.pages)-1) {
+			break
+		}
+	}
+
+	return nil
+}
+
+func TestFindDBInstances(t *testing.T) {
+	conn := &mockDBInstancesPagesConn{
+		pages: [][]*rds.DBInstance{
+			{
+				{DBInstanceIdentifier: aws.String("test-instance-1")},
+				{DBInstanceIdentifier: aws.String("test-instance-2")},
+			},
+			{
+				nil,
+				{DBInstanceIdentifier: aws.String("test-instance-3")},
+			},
+			{},
+			{
+				{DBInstanceIdentifier: aws.String("test-instance-4")},
+			},
+		},
+	}
+	input := &rds.DescribeDBInstancesInput{
+		Filters: []*rds.Filter{{
+			Name:   aws.String("db-cluster-id"),
+			Values: aws.StringSlice([]string{"test-cluster"}),
+		}},
+	}
+
+	dbInstances, err := tfrds.FindDBInstances(conn, input)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var got []string
+	for _, dbInstance := range dbInstances {
+		got = append(got, aws.StringValue(dbInstance.DBInstanceIdentifier))
+	}
+
+	if expected := []string{"test-instance-1", "test-instance-2", "test-instance-3", "test-instance-4"}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("got DB instances %v, expected %v", got, expected)
+	}
+
+	if conn.input != input {
+		t.Error("expected the input, including its filters, to be passed to DescribeDBInstances")
+	}
+}
+
+type mockPendingMaintenanceActionsConn struct {
+	rdsiface.RDSAPI
+
+	pages [][]*rds.ResourcePendingMaintenanceActions
+	input *rds.DescribePendingMaintenanceActionsInput
+}
+
+func (m *mockPendingMaintenanceActionsConn) DescribePendingMaintenanceActionsPages(input *rds.DescribePendingMaintenanceActionsInput, fn func(*rds.DescribePendingMaintenanceActionsOutput, bool) bool) error {
+	m.input = input
+
+	for i, page := range m.pages {
+		if !fn(&rds.DescribePendingMaintenanceActionsOutput{PendingMaintenanceActions: page}, i == len(m.pages)-1) {
+			break
+		}
+	}
+
+	return nil
+}
+
+func TestFindPendingMaintenanceActionsByResourceARN(t *testing.T) {
+	arn := "arn:aws:rds:us-west-2:123456789012:db:test-instance"
+	conn := &mockPendingMaintenanceActionsConn{
+		pages: [][]*rds.ResourcePendingMaintenanceActions{
+			{
+				{
+					ResourceIdentifier: aws.String(arn),
+					PendingMaintenanceActionDetails: []*rds.PendingMaintenanceAction{
+						{Action: aws.String("system-update")},
+						nil,
+					},
+				},
+			},
+			{
+				nil,
+				{
+					ResourceIdentifier: aws.String(arn + "-other"),
+					PendingMaintenanceActionDetails: []*rds.PendingMaintenanceAction{
+						{Action: aws.String("db-upgrade")},
+					},
+				},
+				{
+					ResourceIdentifier: aws.String(arn),
+					PendingMaintenanceActionDetails: []*rds.PendingMaintenanceAction{
+						{Action: aws.String("ca-certificate-rotation")},
+					},
+				},
+			},
+		},
+	}
+
+	actions, err := tfrds.FindPendingMaintenanceActionsByResourceARN(conn, arn)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var got []string
+	for _, action := range actions {
+		got = append(got, aws.StringValue(action.Action))
+	}
+
+	if expected := []string{"system-update", "ca-certificate-rotation"}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("got actions %v, expected %v", got, expected)
+	}
+
+	if got := aws.StringValue(conn.input.ResourceIdentifier); got != arn {
+		t.Errorf("got resource identifier %s, expected %s", got, arn)
+	}
+}
+
+type mockApplyPendingMaintenanceActionConn struct {
+	mockPendingMaintenanceActionsConn
+
+	applied []*rds.ApplyPendingMaintenanceActionInput
+}
+
+func (m *mockApplyPendingMaintenanceActionConn) ApplyPendingMaintenanceAction(input *rds.ApplyPendingMaintenanceActionInput) (*rds.ApplyPendingMaintenanceActionOutput, error) {
+	m.applied = append(m.applied, input)
+
+	return &rds.ApplyPendingMaintenanceActionOutput{}, nil
+}
+
+func TestApplyClusterInstancePendingMaintenanceActions(t *testing.T) {
+	arn := "arn:aws:rds:us-west-2:123456789012:db:test-instance"
+
+	testCases := []struct {
+		Description string
+		Actions     []*rds.PendingMaintenanceAction
+		OptInType   string
+		Expected    []string
+	}{
+		{
+			Description: "actions pending",
+			Actions: []*rds.PendingMaintenanceAction{
+				{Action: aws.String("system-update")},
+				{Action: aws.String("db-upgrade"), OptInStatus: aws.String(tfrds.OptInTypeNextMaintenance)},
+			},
+			OptInType: tfrds.OptInTypeImmediate,
+			Expected:  []string{"system-update", "db-upgrade"},
+		},
+		{
+			Description: "actions already opted in",
+			Actions: []*rds.PendingMaintenanceAction{
+				{Action: aws.String("system-update"), OptInStatus: aws.String(tfrds.OptInTypeNextMaintenance)},
+				{Action: aws.String("db-upgrade")},
+			},
+			OptInType: tfrds.OptInTypeNextMaintenance,
+			Expected:  []string{"db-upgrade"},
+		},
+		{
+			Description: "no actions pending",
+			OptInType:   tfrds.OptInTypeImmediate,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Description, func(t *testing.T) {
+			conn := &mockApplyPendingMaintenanceActionConn{
+				mockPendingMaintenanceActionsConn: mockPendingMaintenanceActionsConn{
+					pages: [][]*rds.ResourcePendingMaintenanceActions{{{
+						ResourceIdentifier:              aws.String(arn),
+						PendingMaintenanceActionDetails: testCase.Actions,
+					}}},
+				},
+			}
+
+			got, err := tfrds.ApplyClusterInstancePendingMaintenanceActions(conn, arn, testCase.OptInType)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !reflect.DeepEqual(got, testCase.Expected) {
+				t.Errorf("got applied actions %v, expected %v", got, testCase.Expected)
+			}
+
+			if len(conn.applied) != len(testCase.Expected) {
+				t.Fatalf("got %d ApplyPendingMaintenanceAction calls, expected %d", len(conn.applied), len(testCase.Expected))
+			}
+
+			for i, input := range conn.applied {
+				if aws.StringValue(input.ApplyAction) != testCase.Expected[i] || aws.StringValue(input.OptInType) != testCase.OptInType || aws.StringValue(input.ResourceIdentifier) != arn {
+					t.Errorf("unexpected ApplyPendingMaintenanceAction input: %s", input)
+				}
+			}
+		})
+	}
+}
+
+func TestFindDBClusterInstanceByTwoPartID(t *testing.T) {
+	conn := &mockClusterInstancesConn{
+		instances: []*rds.DBInstance{
+			{DBInstanceIdentifier: aws.String("test-instance"), DBClusterIdentifier: aws.String("test-cluster")},
+			{DBInstanceIdentifier: aws.String("standalone-instance")},
+		},
+	}
+
+	testCases := []struct {
+		Description   string
+		ClusterID     string
+		InstanceID    string
+		ExpectedError string
+	}{
+		{
+			Description: "member",
+			ClusterID:   "test-cluster",
+			InstanceID:  "test-instance",
+		},
+		{
+			Description:   "member of other cluster",
+			ClusterID:     "other-cluster",
+			InstanceID:    "test-instance",
+			ExpectedError: "RDS DB Instance (test-instance) is not a member of RDS Cluster (other-cluster), it is a member of RDS Cluster (test-cluster)",
+		},
+		{
+			Description:   "not a cluster member",
+			ClusterID:     "test-cluster",
+			InstanceID:    "standalone-instance",
+			ExpectedError: "RDS DB Instance (standalone-instance) is not a member of RDS Cluster (test-cluster)",
+		},
+		{
+			Description:   "instance not found",
+			ClusterID:     "test-cluster",
+			InstanceID:    "missing-instance",
+			ExpectedError: "couldn't find resource",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Description, func(t *testing.T) {
+			got, err := tfrds.FindDBClusterInstanceByTwoPartID(conn, testCase.ClusterID, testCase.InstanceID)
+
+			if testCase.ExpectedError == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				if got, expected := aws.StringValue(got.DBInstanceIdentifier), testCase.InstanceID; got != expected {
+					t.Errorf("got DBInstanceIdentifier %q, expected %q", got, expected)
+				}
+
+				return
+			}
+
+			if !tfresource.NotFound(err) {
+				t.Fatalf("expected NotFound error, got: %v", err)
+			}
+
+			if !strings.Contains(err.Error(), testCase.ExpectedError) {
+				t.Errorf("expected error to include %q, got: %s", testCase.ExpectedError, err)
+			}
+		})
+	}
+}
+
 func TestAccRDSClusterInstance_basic(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping long-running test in short mode")
 	}
 
-	var v rds.DBInstance
-	resourceName := "aws_rds_cluster_instance.cluster_instances"
+	var v rds.DBInstance
+	resourceName := "aws_rds_cluster_instance.cluster_instances"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ErrorCheck:        acctest.ErrorCheck(t, rds.EndpointsID),
+		ProviderFactories: acctest.ProviderFactories,
+		CheckDestroy:      testAccCheckClusterInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccClusterInstanceConfig_basic(sdkacctest.RandInt()),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckClusterInstanceExists(resourceName, &v),
+					testAccCheckClusterInstanceAttributes(&v),
+					acctest.MatchResourceAttrRegionalARN(resourceName, "arn", "rds", regexp.MustCompile(`db:.+`)),
+					resource.TestCheckResourceAttr(resourceName, "auto_minor_version_upgrade", "true"),
+					resource.TestCheckResourceAttr(resourceName, "copy_tags_to_snapshot", "false"),
+					resource.TestCheckResourceAttrSet(resourceName, "preferred_maintenance_window"),
+					resource.TestCheckResourceAttrSet(resourceName, "preferred_backup_window"),
+					resource.TestCheckResourceAttrSet(resourceName, "dbi_resource_id"),
+					resource.TestCheckResourceAttrPair(resourceName, "performance_insights_resource_id", resourceName, "dbi_resource_id"),
+					resource.TestCheckResourceAttrSet(resourceName, "availability_zone"),
+					resource.TestCheckResourceAttrSet(resourceName, "engine_version"),
+					resource.TestCheckResourceAttr(resourceName, "engine", "aurora"),
+					resource.TestCheckResourceAttr(resourceName, "engine_version_major", "5.6"),
+					resource.TestCheckResourceAttrSet(resourceName, "endpoint"),
+					resource.TestCheckResourceAttrSet(resourceName, "hosted_zone_id"),
+					resource.TestCheckResourceAttrSet(resourceName, "port"),
+					resource.TestCheckResourceAttr(resourceName, "skip_final_snapshot", "true"),
+					resource.TestCheckResourceAttr(resourceName, "status", "available"),
+					resource.TestCheckResourceAttr(resourceName, "multi_az", "false"),
+					resource.TestCheckResourceAttr(resourceName, "failover_priority", "0"),
+					resource.TestCheckResourceAttr(resourceName, "write_forwarding_enabled", "false"),
+					resource.TestCheckResourceAttr(resourceName, "enabled_cloudwatch_logs_exports.#", "0"),
+					resource.TestCheckResourceAttr(resourceName, "cluster_iam_roles.#", "0"),
+					resource.TestCheckResourceAttr(resourceName, "status_infos.#", "0"),
+					resource.TestCheckResourceAttr(resourceName, "cluster_deletion_protection", "false"),
+					resource.TestCheckResourceAttrPair(resourceName, "http_endpoint_enabled", "aws_rds_cluster.default", "enable_http_endpoint"),
+					resource.TestCheckResourceAttrPair(resourceName, "backup_retention_period", "aws_rds_cluster.default", "backup_retention_period"),
+					resource.TestCheckResourceAttrPair(resourceName, "backup_window", "aws_rds_cluster.default", "preferred_backup_window"),
+					resource.TestCheckResourceAttr(resourceName, "backup_window_overlaps_maintenance", "false"),
+					resource.TestCheckResourceAttr(resourceName, "engine_version_matches_cluster", "true"),
+					resource.TestCheckResourceAttrPair(resourceName, "writer_endpoint", resourceName, "endpoint"),
+					resource.TestCheckResourceAttrPair(resourceName, "cluster_endpoint", "aws_rds_cluster.default", "endpoint"),
+					resource.TestCheckResourceAttrPair(resourceName, "cluster_reader_endpoint", "aws_rds_cluster.default", "reader_endpoint"),
+					resource.TestCheckResourceAttrPair(resourceName, "vpc_security_group_ids.#", "aws_rds_cluster.default", "vpc_security_group_ids.#"),
+					resource.TestCheckResourceAttr(resourceName, "wait_for_connectivity", "false"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"apply_immediately",
+					"identifier_prefix",
+				},
+			},
+			{
+				Config: testAccClusterInstanceConfig_modified(sdkacctest.RandInt()),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckClusterInstanceExists(resourceName, &v),
+					testAccCheckClusterInstanceAttributes(&v),
+					resource.TestCheckResourceAttr(resourceName, "auto_minor_version_upgrade", "false"),
+				),
+			},
+		},
+	})
+}
+
+// TestAccRDSClusterInstance_importRoundTrip verifies that importing a cluster instance
+// repopulates every attribute other than the write-only apply_immediately.
+func TestAccRDSClusterInstance_importRoundTrip(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping long-running test in short mode")
+	}
+
+	var v rds.DBInstance
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	resourceName := "aws_rds_cluster_instance.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ErrorCheck:        acctest.ErrorCheck(t, rds.EndpointsID),
+		ProviderFactories: acctest.ProviderFactories,
+		CheckDestroy:      testAccCheckClusterInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccClusterInstanceConfig_importRoundTrip(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckClusterInstanceExists(resourceName, &v),
+					resource.TestCheckResourceAttr(resourceName, "identifier_prefix", "tf-acc-test-import-"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"apply_immediately",
+				},
+			},
+		},
+	})
+}
+
+func TestAccRDSClusterInstance_isAlreadyBeingDeleted(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping long-running test in short mode")
+	}
+
+	var v rds.DBInstance
+	resourceName := "aws_rds_cluster_instance.cluster_instances"
+	rInt := sdkacctest.RandInt()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ErrorCheck:        acctest.ErrorCheck(t, rds.EndpointsID),
+		ProviderFactories: acctest.ProviderFactories,
+		CheckDestroy:      testAccCheckClusterInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccClusterInstanceConfig_basic(rInt),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckClusterInstanceExists(resourceName, &v),
+				),
+			},
+			{
+				PreConfig: func() {
+					// Get Database Instance into deleting state
+					conn := acctest.Provider.Meta().(*conns.AWSClient).RDSConn
+					input := &rds.DeleteDBInstanceInput{
+						DBInstanceIdentifier: aws.String(fmt.Sprintf("tf-cluster-instance-%d", rInt)),
+						SkipFinalSnapshot:    aws.Bool(true),
+					}
+					_, err := conn.DeleteDBInstance(input)
+					if err != nil {
+						t.Fatalf("error deleting Database Instance: %s", err)
+					}
+				},
+				Config:  testAccClusterInstanceConfig_basic(rInt),
+				Destroy: true,
+			},
+		},
+	})
+}
+
+func TestAccRDSClusterInstance_az(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping long-running test in short mode")
+	}
+
+	var v rds.DBInstance
+	resourceName := "aws_rds_cluster_instance.cluster_instances"
+	availabilityZonesDataSourceName := "data.aws_availability_zones.available"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ErrorCheck:        acctest.ErrorCheck(t, rds.EndpointsID),
+		ProviderFactories: acctest.ProviderFactories,
+		CheckDestroy:      testAccCheckClusterInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccClusterInstanceConfig_az(sdkacctest.RandInt()),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckClusterInstanceExists(resourceName, &v),
+					testAccCheckClusterInstanceAttributes(&v),
+					resource.TestCheckResourceAttrPair(resourceName, "availability_zone", availabilityZonesDataSourceName, "names.0"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"apply_immediately",
+					"identifier_prefix",
+				},
+			},
+		},
+	})
+}
+
+func TestAccRDSClusterInstance_namePrefix(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping long-running test in short mode")
+	}
+
+	var v rds.DBInstance
+	rInt := sdkacctest.RandInt()
+	resourceName := "aws_rds_cluster_instance.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ErrorCheck:        acctest.ErrorCheck(t, rds.EndpointsID),
+		ProviderFactories: acctest.ProviderFactories,
+		CheckDestroy:      testAccCheckClusterInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccClusterInstanceConfig_namePrefix(rInt),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckClusterInstanceExists(resourceName, &v),
+					testAccCheckClusterInstanceAttributes(&v),
+					resource.TestCheckResourceAttr(resourceName, "db_subnet_group_name", fmt.Sprintf("tf-test-%d", rInt)),
+					resource.TestMatchResourceAttr(resourceName, "identifier", regexp.MustCompile("^tf-cluster-instance-")),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"apply_immediately",
+					"identifier_prefix",
+				},
+			},
+		},
+	})
+}
+
+func TestAccRDSClusterInstance_generatedName(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping long-running test in short mode")
+	}
+
+	var v rds.DBInstance
+	resourceName := "aws_rds_cluster_instance.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ErrorCheck:        acctest.ErrorCheck(t, rds.EndpointsID),
+		ProviderFactories: acctest.ProviderFactories,
+		CheckDestroy:      testAccCheckClusterInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccClusterInstanceConfig_generatedName(sdkacctest.RandInt()),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckClusterInstanceExists(resourceName, &v),
+					testAccCheckClusterInstanceAttributes(&v),
+					resource.TestMatchResourceAttr(resourceName, "identifier", regexp.MustCompile("^tf-")),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"apply_immediately",
+					"identifier_prefix",
+				},
+			},
+		},
+	})
+}
+
+func TestAccRDSClusterInstance_generatedNameDefaultIdentifierPrefix(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping long-running test in short mode")
+	}
+
+	var v rds.DBInstance
+	resourceName := "aws_rds_cluster_instance.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ErrorCheck:        acctest.ErrorCheck(t, rds.EndpointsID),
+		ProviderFactories: acctest.ProviderFactories,
+		CheckDestroy:      testAccCheckClusterInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: acctest.ConfigCompose(
+					testAccClusterInstanceConfig_providerDefaultIdentifierPrefix("tf-acc-default-"),
+					testAccClusterInstanceConfig_generatedName(sdkacctest.RandInt()),
+				),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckClusterInstanceExists(resourceName, &v),
+					resource.TestMatchResourceAttr(resourceName, "identifier", regexp.MustCompile("^tf-acc-default-")),
+				),
+			},
+		},
+	})
+}
+
+func TestAccRDSClusterInstance_kmsKey(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping long-running test in short mode")
+	}
+
+	var v rds.DBInstance
+	kmsKeyResourceName := "aws_kms_key.foo"
+	resourceName := "aws_rds_cluster_instance.cluster_instances"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ErrorCheck:        acctest.ErrorCheck(t, rds.EndpointsID),
+		ProviderFactories: acctest.ProviderFactories,
+		CheckDestroy:      testAccCheckClusterInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccClusterInstanceConfig_kmsKey(sdkacctest.RandInt()),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckClusterInstanceExists(resourceName, &v),
+					resource.TestCheckResourceAttrPair(resourceName, "kms_key_id", kmsKeyResourceName, "arn"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"apply_immediately",
+					"identifier_prefix",
+				},
+			},
+		},
+	})
+}
+
+// https://github.com/hashicorp/terraform/issues/5350
+func TestAccRDSClusterInstance_disappears(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping long-running test in short mode")
+	}
+
+	var v rds.DBInstance
+	resourceName := "aws_rds_cluster_instance.cluster_instances"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ErrorCheck:        acctest.ErrorCheck(t, rds.EndpointsID),
+		ProviderFactories: acctest.ProviderFactories,
+		CheckDestroy:      testAccCheckClusterInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccClusterInstanceConfig_basic(sdkacctest.RandInt()),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckClusterInstanceExists(resourceName, &v),
+					acctest.CheckResourceDisappears(acctest.Provider, tfrds.ResourceClusterInstance(), resourceName),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestAccRDSClusterInstance_publiclyAccessible(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping long-running test in short mode")
+	}
+
+	var dbInstance rds.DBInstance
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	resourceName := "aws_rds_cluster_instance.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ErrorCheck:        acctest.ErrorCheck(t, rds.EndpointsID),
+		ProviderFactories: acctest.ProviderFactories,
+		CheckDestroy:      testAccCheckClusterInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccClusterInstanceConfig_publiclyAccessible(rName, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckClusterInstanceExists(resourceName, &dbInstance),
+					resource.TestCheckResourceAttr(resourceName, "publicly_accessible", "true"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"apply_immediately",
+					"identifier_prefix",
+				},
+			},
+			{
+				Config: testAccClusterInstanceConfig_publiclyAccessible(rName, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckClusterInstanceExists(resourceName, &dbInstance),
+					resource.TestCheckResourceAttr(resourceName, "publicly_accessible", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccRDSClusterInstance_copyTagsToSnapshot(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping long-running test in short mode")
+	}
+
+	var dbInstance rds.DBInstance
+	rNameSuffix := sdkacctest.RandInt()
+	resourceName := "aws_rds_cluster_instance.cluster_instances"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ErrorCheck:        acctest.ErrorCheck(t, rds.EndpointsID),
+		ProviderFactories: acctest.ProviderFactories,
+		CheckDestroy:      testAccCheckClusterInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccClusterInstanceConfig_copyTagsToSnapshot(rNameSuffix, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckClusterInstanceExists(resourceName, &dbInstance),
+					resource.TestCheckResourceAttr(resourceName, "copy_tags_to_snapshot", "true"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"apply_immediately",
+					"identifier_prefix",
+				},
+			},
+			{
+				Config: testAccClusterInstanceConfig_copyTagsToSnapshot(rNameSuffix, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckClusterInstanceExists(resourceName, &dbInstance),
+					resource.TestCheckResourceAttr(resourceName, "copy_tags_to_snapshot", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccRDSClusterInstance_monitoringInterval(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping long-running test in short mode")
+	}
+
+	var dbInstance rds.DBInstance
+	resourceName := "aws_rds_cluster_instance.test"
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ErrorCheck:        acctest.ErrorCheck(t, rds.EndpointsID),
+		ProviderFactories: acctest.ProviderFactories,
+		CheckDestroy:      testAccCheckInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccClusterInstanceConfig_monitoringInterval(rName, 30),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckClusterInstanceExists(resourceName, &dbInstance),
+					resource.TestCheckResourceAttr(resourceName, "monitoring_interval", "30"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"apply_immediately",
+					"identifier_prefix",
+				},
+			},
+			{
+				Config: testAccClusterInstanceConfig_monitoringInterval(rName, 60),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckClusterInstanceExists(resourceName, &dbInstance),
+					resource.TestCheckResourceAttr(resourceName, "monitoring_interval", "60"),
+				),
+			},
+			{
+				Config: testAccClusterInstanceConfig_monitoringInterval(rName, 0),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckClusterInstanceExists(resourceName, &dbInstance),
+					resource.TestCheckResourceAttr(resourceName, "monitoring_interval", "0"),
+				),
+			},
+			{
+				Config: testAccClusterInstanceConfig_monitoringInterval(rName, 30),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckClusterInstanceExists(resourceName, &dbInstance),
+					resource.TestCheckResourceAttr(resourceName, "monitoring_interval", "30"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccRDSClusterInstance_MonitoringRoleARN_enabledToDisabled(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping long-running test in short mode")
+	}
+
+	var dbInstance rds.DBInstance
+	iamRoleResourceName := "aws_iam_role.test"
+	resourceName := "aws_rds_cluster_instance.test"
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ErrorCheck:        acctest.ErrorCheck(t, rds.EndpointsID),
+		ProviderFactories: acctest.ProviderFactories,
+		CheckDestroy:      testAccCheckInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccClusterInstanceConfig_monitoringRoleARN(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckClusterInstanceExists(resourceName, &dbInstance),
+					resource.TestCheckResourceAttrPair(resourceName, "monitoring_role_arn", iamRoleResourceName, "arn"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"apply_immediately",
+					"identifier_prefix",
+				},
+			},
+			{
+				Config: testAccClusterInstanceConfig_monitoringInterval(rName, 0),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckClusterInstanceExists(resourceName, &dbInstance),
+					resource.TestCheckResourceAttr(resourceName, "monitoring_interval", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccRDSClusterInstance_MonitoringRoleARN_enabledToRemoved(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping long-running test in short mode")
+	}
+
+	var dbInstance rds.DBInstance
+	iamRoleResourceName := "aws_iam_role.test"
+	resourceName := "aws_rds_cluster_instance.test"
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ErrorCheck:        acctest.ErrorCheck(t, rds.EndpointsID),
+		ProviderFactories: acctest.ProviderFactories,
+		CheckDestroy:      testAccCheckInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccClusterInstanceConfig_monitoringRoleARN(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckClusterInstanceExists(resourceName, &dbInstance),
+					resource.TestCheckResourceAttrPair(resourceName, "monitoring_role_arn", iamRoleResourceName, "arn"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"apply_immediately",
+					"identifier_prefix",
+				},
+			},
+			{
+				Config: testAccClusterInstanceConfig_monitoringRoleARNRemoved(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckClusterInstanceExists(resourceName, &dbInstance),
+				),
+			},
+		},
+	})
+}
+
+func TestAccRDSClusterInstance_MonitoringRoleARN_enabledToMonitoringDisabled(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping long-running test in short mode")
+	}
+
+	var dbInstance rds.DBInstance
+	iamRoleResourceName := "aws_iam_role.test"
+	resourceName := "aws_rds_cluster_instance.test"
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ErrorCheck:        acctest.ErrorCheck(t, rds.EndpointsID),
+		ProviderFactories: acctest.ProviderFactories,
+		CheckDestroy:      testAccCheckClusterInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccClusterInstanceConfig_monitoringRoleARN(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckClusterInstanceExists(resourceName, &dbInstance),
+					resource.TestCheckResourceAttr(resourceName, "monitoring_interval", "5"),
+					resource.TestCheckResourceAttrPair(resourceName, "monitoring_role_arn", iamRoleResourceName, "arn"),
+				),
+			},
+			{
+				Config: testAccClusterInstanceConfig_monitoringDisabled(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckClusterInstanceExists(resourceName, &dbInstance),
+					resource.TestCheckResourceAttr(resourceName, "monitoring_interval", "0"),
+					resource.TestCheckResourceAttr(resourceName, "monitoring_role_arn", ""),
+				),
+			},
+		},
+	})
+}
+
+func TestAccRDSClusterInstance_MonitoringRoleARN_removedToEnabled(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping long-running test in short mode")
+	}
+
+	var dbInstance rds.DBInstance
+	iamRoleResourceName := "aws_iam_role.test"
+	resourceName := "aws_rds_cluster_instance.test"
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ErrorCheck:        acctest.ErrorCheck(t, rds.EndpointsID),
+		ProviderFactories: acctest.ProviderFactories,
+		CheckDestroy:      testAccCheckInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccClusterInstanceConfig_monitoringRoleARNRemoved(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckClusterInstanceExists(resourceName, &dbInstance),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"apply_immediately",
+					"identifier_prefix",
+				},
+			},
+			{
+				Config: testAccClusterInstanceConfig_monitoringRoleARN(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckClusterInstanceExists(resourceName, &dbInstance),
+					resource.TestCheckResourceAttrPair(resourceName, "monitoring_role_arn", iamRoleResourceName, "arn"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccRDSClusterInstance_PerformanceInsightsEnabled_auroraMySQL1(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping long-running test in short mode")
+	}
+
+	var dbInstance rds.DBInstance
+	resourceName := "aws_rds_cluster_instance.test"
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	engine := "aurora"
 
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:          func() { acctest.PreCheck(t) },
+		PreCheck:          func() { acctest.PreCheck(t); testAccPerformanceInsightsDefaultVersionPreCheck(t, engine) },
 		ErrorCheck:        acctest.ErrorCheck(t, rds.EndpointsID),
 		ProviderFactories: acctest.ProviderFactories,
 		CheckDestroy:      testAccCheckClusterInstanceDestroy,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccClusterInstanceConfig_basic(sdkacctest.RandInt()),
+				Config: testAccClusterInstanceConfig_performanceInsightsEnabledAuroraMySQL1(rName, engine),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckClusterInstanceExists(resourceName, &v),
-					testAccCheckClusterInstanceAttributes(&v),
-					acctest.MatchResourceAttrRegionalARN(resourceName, "arn", "rds", regexp.MustCompile(`db:.+`)),
-					resource.TestCheckResourceAttr(resourceName, "auto_minor_version_upgrade", "true"),
-					resource.TestCheckResourceAttr(resourceName, "copy_tags_to_snapshot", "false"),
-					resource.TestCheckResourceAttrSet(resourceName, "preferred_maintenance_window"),
-					resource.TestCheckResourceAttrSet(resourceName, "preferred_backup_window"),
-					resource.TestCheckResourceAttrSet(resourceName, "dbi_resource_id"),
-					resource.TestCheckResourceAttrSet(resourceName, "availability_zone"),
-					resource.TestCheckResourceAttrSet(resourceName, "engine_version"),
-					resource.TestCheckResourceAttr(resourceName, "engine", "aurora"),
+					testAccCheckClusterInstanceExists(resourceName, &dbInstance),
+					resource.TestCheckResourceAttr(resourceName, "performance_insights_enabled", "true"),
 				),
 			},
 			{
@@ -57,80 +2319,68 @@ func TestAccRDSClusterInstance_basic(t *testing.T) {
 					"identifier_prefix",
 				},
 			},
-			{
-				Config: testAccClusterInstanceConfig_modified(sdkacctest.RandInt()),
-				Check: resource.ComposeTestCheckFunc(
-					testAccCheckClusterInstanceExists(resourceName, &v),
-					testAccCheckClusterInstanceAttributes(&v),
-					resource.TestCheckResourceAttr(resourceName, "auto_minor_version_upgrade", "false"),
-				),
-			},
 		},
 	})
 }
 
-func TestAccRDSClusterInstance_isAlreadyBeingDeleted(t *testing.T) {
+func TestAccRDSClusterInstance_PerformanceInsightsEnabled_auroraMySQL2(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping long-running test in short mode")
 	}
 
-	var v rds.DBInstance
-	resourceName := "aws_rds_cluster_instance.cluster_instances"
-	rInt := sdkacctest.RandInt()
+	var dbInstance rds.DBInstance
+	resourceName := "aws_rds_cluster_instance.test"
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	engine := "aurora-mysql"
+	engineVersion := "5.7.mysql_aurora.2.04.2"
 
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:          func() { acctest.PreCheck(t) },
+		PreCheck:          func() { acctest.PreCheck(t); testAccPerformanceInsightsPreCheck(t, engine, engineVersion) },
 		ErrorCheck:        acctest.ErrorCheck(t, rds.EndpointsID),
 		ProviderFactories: acctest.ProviderFactories,
 		CheckDestroy:      testAccCheckClusterInstanceDestroy,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccClusterInstanceConfig_basic(rInt),
+				Config: testAccClusterInstanceConfig_performanceInsightsEnabledAuroraMySQL2(rName, engine, engineVersion),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckClusterInstanceExists(resourceName, &v),
+					testAccCheckClusterInstanceExists(resourceName, &dbInstance),
+					resource.TestCheckResourceAttr(resourceName, "performance_insights_enabled", "true"),
 				),
 			},
 			{
-				PreConfig: func() {
-					// Get Database Instance into deleting state
-					conn := acctest.Provider.Meta().(*conns.AWSClient).RDSConn
-					input := &rds.DeleteDBInstanceInput{
-						DBInstanceIdentifier: aws.String(fmt.Sprintf("tf-cluster-instance-%d", rInt)),
-						SkipFinalSnapshot:    aws.Bool(true),
-					}
-					_, err := conn.DeleteDBInstance(input)
-					if err != nil {
-						t.Fatalf("error deleting Database Instance: %s", err)
-					}
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"apply_immediately",
+					"identifier_prefix",
 				},
-				Config:  testAccClusterInstanceConfig_basic(rInt),
-				Destroy: true,
 			},
 		},
 	})
 }
 
-func TestAccRDSClusterInstance_az(t *testing.T) {
+func TestAccRDSClusterInstance_PerformanceInsightsEnabled_auroraPostgresql(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping long-running test in short mode")
 	}
 
-	var v rds.DBInstance
-	resourceName := "aws_rds_cluster_instance.cluster_instances"
-	availabilityZonesDataSourceName := "data.aws_availability_zones.available"
+	var dbInstance rds.DBInstance
+	resourceName := "aws_rds_cluster_instance.test"
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	engine := "aurora-postgresql"
 
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:          func() { acctest.PreCheck(t) },
+		PreCheck:          func() { acctest.PreCheck(t); testAccPerformanceInsightsDefaultVersionPreCheck(t, engine) },
 		ErrorCheck:        acctest.ErrorCheck(t, rds.EndpointsID),
 		ProviderFactories: acctest.ProviderFactories,
 		CheckDestroy:      testAccCheckClusterInstanceDestroy,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccClusterInstanceConfig_az(sdkacctest.RandInt()),
+				Config: testAccClusterInstanceConfig_performanceInsightsEnabledAuroraPostgresql(rName, engine),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckClusterInstanceExists(resourceName, &v),
-					testAccCheckClusterInstanceAttributes(&v),
-					resource.TestCheckResourceAttrPair(resourceName, "availability_zone", availabilityZonesDataSourceName, "names.0"),
+					testAccCheckClusterInstanceExists(resourceName, &dbInstance),
+					resource.TestCheckResourceAttr(resourceName, "performance_insights_enabled", "true"),
 				),
 			},
 			{
@@ -146,28 +2396,29 @@ func TestAccRDSClusterInstance_az(t *testing.T) {
 	})
 }
 
-func TestAccRDSClusterInstance_namePrefix(t *testing.T) {
+func TestAccRDSClusterInstance_PerformanceInsightsKMSKeyID_auroraMySQL1(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping long-running test in short mode")
 	}
 
-	var v rds.DBInstance
-	rInt := sdkacctest.RandInt()
+	var dbInstance rds.DBInstance
+	kmsKeyResourceName := "aws_kms_key.test"
 	resourceName := "aws_rds_cluster_instance.test"
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	engine := "aurora"
 
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:          func() { acctest.PreCheck(t) },
+		PreCheck:          func() { acctest.PreCheck(t); testAccPerformanceInsightsDefaultVersionPreCheck(t, engine) },
 		ErrorCheck:        acctest.ErrorCheck(t, rds.EndpointsID),
 		ProviderFactories: acctest.ProviderFactories,
 		CheckDestroy:      testAccCheckClusterInstanceDestroy,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccClusterInstanceConfig_namePrefix(rInt),
+				Config: testAccClusterInstanceConfig_performanceInsightsKMSKeyIDAuroraMySQL1(rName, engine),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckClusterInstanceExists(resourceName, &v),
-					testAccCheckClusterInstanceAttributes(&v),
-					resource.TestCheckResourceAttr(resourceName, "db_subnet_group_name", fmt.Sprintf("tf-test-%d", rInt)),
-					resource.TestMatchResourceAttr(resourceName, "identifier", regexp.MustCompile("^tf-cluster-instance-")),
+					testAccCheckClusterInstanceExists(resourceName, &dbInstance),
+					resource.TestCheckResourceAttr(resourceName, "performance_insights_enabled", "true"),
+					resource.TestCheckResourceAttrPair(resourceName, "performance_insights_kms_key_id", kmsKeyResourceName, "arn"),
 				),
 			},
 			{
@@ -183,26 +2434,27 @@ func TestAccRDSClusterInstance_namePrefix(t *testing.T) {
 	})
 }
 
-func TestAccRDSClusterInstance_generatedName(t *testing.T) {
+func TestAccRDSClusterInstance_PerformanceInsightsKMSKeyIDAuroraMySQL1_defaultKeyToCustomKey(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping long-running test in short mode")
 	}
 
-	var v rds.DBInstance
+	var dbInstance rds.DBInstance
 	resourceName := "aws_rds_cluster_instance.test"
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	engine := "aurora"
 
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:          func() { acctest.PreCheck(t) },
+		PreCheck:          func() { acctest.PreCheck(t); testAccPerformanceInsightsDefaultVersionPreCheck(t, engine) },
 		ErrorCheck:        acctest.ErrorCheck(t, rds.EndpointsID),
 		ProviderFactories: acctest.ProviderFactories,
 		CheckDestroy:      testAccCheckClusterInstanceDestroy,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccClusterInstanceConfig_generatedName(sdkacctest.RandInt()),
+				Config: testAccClusterInstanceConfig_performanceInsightsEnabledAuroraMySQL1(rName, engine),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckClusterInstanceExists(resourceName, &v),
-					testAccCheckClusterInstanceAttributes(&v),
-					resource.TestMatchResourceAttr(resourceName, "identifier", regexp.MustCompile("^tf-")),
+					testAccCheckClusterInstanceExists(resourceName, &dbInstance),
+					resource.TestCheckResourceAttr(resourceName, "performance_insights_enabled", "true"),
 				),
 			},
 			{
@@ -214,30 +2466,38 @@ func TestAccRDSClusterInstance_generatedName(t *testing.T) {
 					"identifier_prefix",
 				},
 			},
+			{
+				Config:      testAccClusterInstanceConfig_performanceInsightsKMSKeyIDAuroraMySQL1(rName, engine),
+				ExpectError: regexp.MustCompile(`InvalidParameterCombination: You .* change your Performance Insights KMS key`),
+			},
 		},
 	})
 }
 
-func TestAccRDSClusterInstance_kmsKey(t *testing.T) {
+func TestAccRDSClusterInstance_PerformanceInsightsKMSKeyID_auroraMySQL2(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping long-running test in short mode")
 	}
 
-	var v rds.DBInstance
-	kmsKeyResourceName := "aws_kms_key.foo"
-	resourceName := "aws_rds_cluster_instance.cluster_instances"
+	var dbInstance rds.DBInstance
+	kmsKeyResourceName := "aws_kms_key.test"
+	resourceName := "aws_rds_cluster_instance.test"
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	engine := "aurora-mysql"
+	engineVersion := "5.7.mysql_aurora.2.04.2"
 
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:          func() { acctest.PreCheck(t) },
+		PreCheck:          func() { acctest.PreCheck(t); testAccPerformanceInsightsPreCheck(t, engine, engineVersion) },
 		ErrorCheck:        acctest.ErrorCheck(t, rds.EndpointsID),
 		ProviderFactories: acctest.ProviderFactories,
 		CheckDestroy:      testAccCheckClusterInstanceDestroy,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccClusterInstanceConfig_kmsKey(sdkacctest.RandInt()),
+				Config: testAccClusterInstanceConfig_performanceInsightsKMSKeyIDAuroraMySQL2(rName, engine, engineVersion),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckClusterInstanceExists(resourceName, &v),
-					resource.TestCheckResourceAttrPair(resourceName, "kms_key_id", kmsKeyResourceName, "arn"),
+					testAccCheckClusterInstanceExists(resourceName, &dbInstance),
+					resource.TestCheckResourceAttr(resourceName, "performance_insights_enabled", "true"),
+					resource.TestCheckResourceAttrPair(resourceName, "performance_insights_kms_key_id", kmsKeyResourceName, "arn"),
 				),
 			},
 			{
@@ -253,53 +2513,68 @@ func TestAccRDSClusterInstance_kmsKey(t *testing.T) {
 	})
 }
 
-// https://github.com/hashicorp/terraform/issues/5350
-func TestAccRDSClusterInstance_disappears(t *testing.T) {
+func TestAccRDSClusterInstance_PerformanceInsightsKMSKeyIDAuroraMySQL2_defaultKeyToCustomKey(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping long-running test in short mode")
 	}
 
-	var v rds.DBInstance
-	resourceName := "aws_rds_cluster_instance.cluster_instances"
+	var dbInstance rds.DBInstance
+	resourceName := "aws_rds_cluster_instance.test"
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	engine := "aurora-mysql"
+	engineVersion := "5.7.mysql_aurora.2.04.2"
 
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:          func() { acctest.PreCheck(t) },
+		PreCheck:          func() { acctest.PreCheck(t); testAccPerformanceInsightsPreCheck(t, engine, engineVersion) },
 		ErrorCheck:        acctest.ErrorCheck(t, rds.EndpointsID),
 		ProviderFactories: acctest.ProviderFactories,
 		CheckDestroy:      testAccCheckClusterInstanceDestroy,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccClusterInstanceConfig_basic(sdkacctest.RandInt()),
+				Config: testAccClusterInstanceConfig_performanceInsightsEnabledAuroraMySQL2(rName, engine, engineVersion),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckClusterInstanceExists(resourceName, &v),
-					acctest.CheckResourceDisappears(acctest.Provider, tfrds.ResourceClusterInstance(), resourceName),
+					testAccCheckClusterInstanceExists(resourceName, &dbInstance),
+					resource.TestCheckResourceAttr(resourceName, "performance_insights_enabled", "true"),
 				),
-				ExpectNonEmptyPlan: true,
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"apply_immediately",
+					"identifier_prefix",
+				},
+			},
+			{
+				Config:      testAccClusterInstanceConfig_performanceInsightsKMSKeyIDAuroraMySQL2(rName, engine, engineVersion),
+				ExpectError: regexp.MustCompile(`InvalidParameterCombination: You .* change your Performance Insights KMS key`),
 			},
 		},
 	})
 }
 
-func TestAccRDSClusterInstance_publiclyAccessible(t *testing.T) {
+func TestAccRDSClusterInstance_performanceInsightsRetentionPeriod(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping long-running test in short mode")
 	}
 
 	var dbInstance rds.DBInstance
-	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
 	resourceName := "aws_rds_cluster_instance.test"
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
 
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:          func() { acctest.PreCheck(t) },
+		PreCheck:          func() { acctest.PreCheck(t); testAccPerformanceInsightsDefaultVersionPreCheck(t, "aurora") },
 		ErrorCheck:        acctest.ErrorCheck(t, rds.EndpointsID),
 		ProviderFactories: acctest.ProviderFactories,
-		CheckDestroy:      testAccCheckClusterInstanceDestroy,
+		CheckDestroy:      testAccCheckClusterDestroy,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccClusterInstanceConfig_publiclyAccessible(rName, true),
+				Config: testAccClusterInstanceConfig_performanceInsightsRetentionPeriod(rName, 731),
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckClusterInstanceExists(resourceName, &dbInstance),
-					resource.TestCheckResourceAttr(resourceName, "publicly_accessible", "true"),
+					resource.TestCheckResourceAttr(resourceName, "performance_insights_enabled", "true"),
+					resource.TestCheckResourceAttr(resourceName, "performance_insights_retention_period", "731"),
 				),
 			},
 			{
@@ -312,36 +2587,86 @@ func TestAccRDSClusterInstance_publiclyAccessible(t *testing.T) {
 				},
 			},
 			{
-				Config: testAccClusterInstanceConfig_publiclyAccessible(rName, false),
+				Config: testAccClusterInstanceConfig_performanceInsightsRetentionPeriod(rName, 7),
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckClusterInstanceExists(resourceName, &dbInstance),
-					resource.TestCheckResourceAttr(resourceName, "publicly_accessible", "false"),
+					resource.TestCheckResourceAttr(resourceName, "performance_insights_enabled", "true"),
+					resource.TestCheckResourceAttr(resourceName, "performance_insights_retention_period", "7"),
+				),
+			},
+			{
+				Config: testAccClusterInstanceConfig_performanceInsightsRetentionPeriod(rName, 155),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckClusterInstanceExists(resourceName, &dbInstance),
+					resource.TestCheckResourceAttr(resourceName, "performance_insights_enabled", "true"),
+					resource.TestCheckResourceAttr(resourceName, "performance_insights_retention_period", "155"),
 				),
 			},
 		},
 	})
 }
 
-func TestAccRDSClusterInstance_copyTagsToSnapshot(t *testing.T) {
+func TestAccRDSClusterInstance_PerformanceInsightsRetentionPeriod_kmsKeyID(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping long-running test in short mode")
 	}
 
 	var dbInstance rds.DBInstance
-	rNameSuffix := sdkacctest.RandInt()
-	resourceName := "aws_rds_cluster_instance.cluster_instances"
+	kmsKeyResourceName := "aws_kms_key.test"
+	resourceName := "aws_rds_cluster_instance.test"
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
 
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:          func() { acctest.PreCheck(t) },
+		PreCheck:          func() { acctest.PreCheck(t); testAccPerformanceInsightsDefaultVersionPreCheck(t, "aurora") },
 		ErrorCheck:        acctest.ErrorCheck(t, rds.EndpointsID),
 		ProviderFactories: acctest.ProviderFactories,
 		CheckDestroy:      testAccCheckClusterInstanceDestroy,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccClusterInstanceConfig_copyTagsToSnapshot(rNameSuffix, true),
+				Config: testAccClusterInstanceConfig_performanceInsightsRetentionPeriodKMSKeyID(rName, 7),
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckClusterInstanceExists(resourceName, &dbInstance),
-					resource.TestCheckResourceAttr(resourceName, "copy_tags_to_snapshot", "true"),
+					resource.TestCheckResourceAttr(resourceName, "performance_insights_enabled", "true"),
+					resource.TestCheckResourceAttrPair(resourceName, "performance_insights_kms_key_id", kmsKeyResourceName, "arn"),
+					resource.TestCheckResourceAttr(resourceName, "performance_insights_retention_period", "7"),
+				),
+			},
+			{
+				Config: testAccClusterInstanceConfig_performanceInsightsRetentionPeriodKMSKeyID(rName, 731),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckClusterInstanceExists(resourceName, &dbInstance),
+					resource.TestCheckResourceAttr(resourceName, "performance_insights_enabled", "true"),
+					resource.TestCheckResourceAttrPair(resourceName, "performance_insights_kms_key_id", kmsKeyResourceName, "arn"),
+					resource.TestCheckResourceAttr(resourceName, "performance_insights_retention_period", "731"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccRDSClusterInstance_PerformanceInsightsKMSKeyID_auroraPostgresql(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping long-running test in short mode")
+	}
+
+	var dbInstance rds.DBInstance
+	kmsKeyResourceName := "aws_kms_key.test"
+	resourceName := "aws_rds_cluster_instance.test"
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	engine := "aurora-postgresql"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t); testAccPerformanceInsightsDefaultVersionPreCheck(t, engine) },
+		ErrorCheck:        acctest.ErrorCheck(t, rds.EndpointsID),
+		ProviderFactories: acctest.ProviderFactories,
+		CheckDestroy:      testAccCheckClusterInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccClusterInstanceConfig_performanceInsightsKMSKeyIDAuroraPostgresql(rName, engine),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckClusterInstanceExists(resourceName, &dbInstance),
+					resource.TestCheckResourceAttr(resourceName, "performance_insights_enabled", "true"),
+					resource.TestCheckResourceAttrPair(resourceName, "performance_insights_kms_key_id", kmsKeyResourceName, "arn"),
 				),
 			},
 			{
@@ -353,18 +2678,11 @@ func TestAccRDSClusterInstance_copyTagsToSnapshot(t *testing.T) {
 					"identifier_prefix",
 				},
 			},
-			{
-				Config: testAccClusterInstanceConfig_copyTagsToSnapshot(rNameSuffix, false),
-				Check: resource.ComposeTestCheckFunc(
-					testAccCheckClusterInstanceExists(resourceName, &dbInstance),
-					resource.TestCheckResourceAttr(resourceName, "copy_tags_to_snapshot", "false"),
-				),
-			},
 		},
 	})
 }
 
-func TestAccRDSClusterInstance_monitoringInterval(t *testing.T) {
+func TestAccRDSClusterInstance_PerformanceInsightsKMSKeyIDAuroraPostgresql_defaultKeyToCustomKey(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping long-running test in short mode")
 	}
@@ -372,18 +2690,19 @@ func TestAccRDSClusterInstance_monitoringInterval(t *testing.T) {
 	var dbInstance rds.DBInstance
 	resourceName := "aws_rds_cluster_instance.test"
 	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	engine := "aurora-postgresql"
 
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:          func() { acctest.PreCheck(t) },
+		PreCheck:          func() { acctest.PreCheck(t); testAccPerformanceInsightsDefaultVersionPreCheck(t, engine) },
 		ErrorCheck:        acctest.ErrorCheck(t, rds.EndpointsID),
 		ProviderFactories: acctest.ProviderFactories,
-		CheckDestroy:      testAccCheckInstanceDestroy,
+		CheckDestroy:      testAccCheckClusterInstanceDestroy,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccClusterInstanceConfig_monitoringInterval(rName, 30),
+				Config: testAccClusterInstanceConfig_performanceInsightsEnabledAuroraPostgresql(rName, engine),
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckClusterInstanceExists(resourceName, &dbInstance),
-					resource.TestCheckResourceAttr(resourceName, "monitoring_interval", "30"),
+					resource.TestCheckResourceAttr(resourceName, "performance_insights_enabled", "true"),
 				),
 			},
 			{
@@ -396,94 +2715,66 @@ func TestAccRDSClusterInstance_monitoringInterval(t *testing.T) {
 				},
 			},
 			{
-				Config: testAccClusterInstanceConfig_monitoringInterval(rName, 60),
-				Check: resource.ComposeTestCheckFunc(
-					testAccCheckClusterInstanceExists(resourceName, &dbInstance),
-					resource.TestCheckResourceAttr(resourceName, "monitoring_interval", "60"),
-				),
-			},
-			{
-				Config: testAccClusterInstanceConfig_monitoringInterval(rName, 0),
-				Check: resource.ComposeTestCheckFunc(
-					testAccCheckClusterInstanceExists(resourceName, &dbInstance),
-					resource.TestCheckResourceAttr(resourceName, "monitoring_interval", "0"),
-				),
-			},
-			{
-				Config: testAccClusterInstanceConfig_monitoringInterval(rName, 30),
-				Check: resource.ComposeTestCheckFunc(
-					testAccCheckClusterInstanceExists(resourceName, &dbInstance),
-					resource.TestCheckResourceAttr(resourceName, "monitoring_interval", "30"),
-				),
+				Config:      testAccClusterInstanceConfig_performanceInsightsKMSKeyIDAuroraPostgresql(rName, engine),
+				ExpectError: regexp.MustCompile(`InvalidParameterCombination: You .* change your Performance Insights KMS key`),
 			},
 		},
 	})
 }
 
-func TestAccRDSClusterInstance_MonitoringRoleARN_enabledToDisabled(t *testing.T) {
+func TestAccRDSClusterInstance_CACertificateIdentifier_unset(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping long-running test in short mode")
 	}
 
 	var dbInstance rds.DBInstance
-	iamRoleResourceName := "aws_iam_role.test"
-	resourceName := "aws_rds_cluster_instance.test"
 	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	resourceName := "aws_rds_cluster_instance.test"
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:          func() { acctest.PreCheck(t) },
 		ErrorCheck:        acctest.ErrorCheck(t, rds.EndpointsID),
 		ProviderFactories: acctest.ProviderFactories,
-		CheckDestroy:      testAccCheckInstanceDestroy,
+		CheckDestroy:      testAccCheckClusterInstanceDestroy,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccClusterInstanceConfig_monitoringRoleARN(rName),
+				Config: testAccClusterInstanceConfig_caCertificateIDUnset(rName),
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckClusterInstanceExists(resourceName, &dbInstance),
-					resource.TestCheckResourceAttrPair(resourceName, "monitoring_role_arn", iamRoleResourceName, "arn"),
+					resource.TestCheckResourceAttrSet(resourceName, "ca_cert_identifier"),
+					testAccCheckClusterInstanceCACertificateIdentifier(resourceName, &dbInstance),
 				),
 			},
 			{
-				ResourceName:      resourceName,
-				ImportState:       true,
-				ImportStateVerify: true,
-				ImportStateVerifyIgnore: []string{
-					"apply_immediately",
-					"identifier_prefix",
-				},
-			},
-			{
-				Config: testAccClusterInstanceConfig_monitoringInterval(rName, 0),
-				Check: resource.ComposeTestCheckFunc(
-					testAccCheckClusterInstanceExists(resourceName, &dbInstance),
-					resource.TestCheckResourceAttr(resourceName, "monitoring_interval", "0"),
-				),
+				Config:   testAccClusterInstanceConfig_caCertificateIDUnset(rName),
+				PlanOnly: true,
 			},
 		},
 	})
 }
 
-func TestAccRDSClusterInstance_MonitoringRoleARN_enabledToRemoved(t *testing.T) {
+func TestAccRDSClusterInstance_caCertificateIdentifier(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping long-running test in short mode")
 	}
 
 	var dbInstance rds.DBInstance
-	iamRoleResourceName := "aws_iam_role.test"
-	resourceName := "aws_rds_cluster_instance.test"
 	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	resourceName := "aws_rds_cluster_instance.test"
+	dataSourceName := "data.aws_rds_certificate.latest"
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:          func() { acctest.PreCheck(t) },
 		ErrorCheck:        acctest.ErrorCheck(t, rds.EndpointsID),
 		ProviderFactories: acctest.ProviderFactories,
-		CheckDestroy:      testAccCheckInstanceDestroy,
+		CheckDestroy:      testAccCheckClusterInstanceDestroy,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccClusterInstanceConfig_monitoringRoleARN(rName),
+				Config: testAccClusterInstanceConfig_caCertificateID(rName),
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckClusterInstanceExists(resourceName, &dbInstance),
-					resource.TestCheckResourceAttrPair(resourceName, "monitoring_role_arn", iamRoleResourceName, "arn"),
+					resource.TestCheckResourceAttrPair(resourceName, "ca_cert_identifier", dataSourceName, "id"),
+					resource.TestCheckResourceAttr(resourceName, "ca_cert_expiring_soon", "false"),
 				),
 			},
 			{
@@ -493,38 +2784,33 @@ func TestAccRDSClusterInstance_MonitoringRoleARN_enabledToRemoved(t *testing.T)
 				ImportStateVerifyIgnore: []string{
 					"apply_immediately",
 					"identifier_prefix",
+					"lookup_ca_cert_expiring_soon",
 				},
 			},
-			{
-				Config: testAccClusterInstanceConfig_monitoringRoleARNRemoved(rName),
-				Check: resource.ComposeTestCheckFunc(
-					testAccCheckClusterInstanceExists(resourceName, &dbInstance),
-				),
-			},
 		},
 	})
 }
 
-func TestAccRDSClusterInstance_MonitoringRoleARN_removedToEnabled(t *testing.T) {
+func TestAccRDSClusterInstance_skipDeleteWait(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping long-running test in short mode")
 	}
 
 	var dbInstance rds.DBInstance
-	iamRoleResourceName := "aws_iam_role.test"
-	resourceName := "aws_rds_cluster_instance.test"
 	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	resourceName := "aws_rds_cluster_instance.test"
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:          func() { acctest.PreCheck(t) },
 		ErrorCheck:        acctest.ErrorCheck(t, rds.EndpointsID),
 		ProviderFactories: acctest.ProviderFactories,
-		CheckDestroy:      testAccCheckInstanceDestroy,
+		CheckDestroy:      testAccCheckClusterInstanceDestroy,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccClusterInstanceConfig_monitoringRoleARNRemoved(rName),
+				Config: testAccClusterInstanceConfig_skipDeleteWait(rName),
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckClusterInstanceExists(resourceName, &dbInstance),
+					resource.TestCheckResourceAttr(resourceName, "skip_delete_wait", "true"),
 				),
 			},
 			{
@@ -537,37 +2823,39 @@ func TestAccRDSClusterInstance_MonitoringRoleARN_removedToEnabled(t *testing.T)
 				},
 			},
 			{
-				Config: testAccClusterInstanceConfig_monitoringRoleARN(rName),
+				Config: testAccClusterInstanceConfig_skipDeleteWaitRemoved(rName),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckClusterInstanceExists(resourceName, &dbInstance),
-					resource.TestCheckResourceAttrPair(resourceName, "monitoring_role_arn", iamRoleResourceName, "arn"),
+					testAccCheckClusterInstanceDeleting(&dbInstance),
 				),
 			},
 		},
 	})
 }
 
-func TestAccRDSClusterInstance_PerformanceInsightsEnabled_auroraMySQL1(t *testing.T) {
+func TestAccRDSClusterInstance_validateBeforeCreate(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping long-running test in short mode")
 	}
 
 	var dbInstance rds.DBInstance
-	resourceName := "aws_rds_cluster_instance.test"
 	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
-	engine := "aurora"
+	resourceName := "aws_rds_cluster_instance.test"
 
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:          func() { acctest.PreCheck(t); testAccPerformanceInsightsDefaultVersionPreCheck(t, engine) },
+		PreCheck:          func() { acctest.PreCheck(t) },
 		ErrorCheck:        acctest.ErrorCheck(t, rds.EndpointsID),
 		ProviderFactories: acctest.ProviderFactories,
 		CheckDestroy:      testAccCheckClusterInstanceDestroy,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccClusterInstanceConfig_performanceInsightsEnabledAuroraMySQL1(rName, engine),
+				Config:      testAccClusterInstanceConfig_validateBeforeCreateInstanceClass(rName, "db.m1.small"),
+				ExpectError: regexp.MustCompile(`error validating RDS Cluster \(.+\) Instance`),
+			},
+			{
+				Config: testAccClusterInstanceConfig_validateBeforeCreate(rName),
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckClusterInstanceExists(resourceName, &dbInstance),
-					resource.TestCheckResourceAttr(resourceName, "performance_insights_enabled", "true"),
+					resource.TestCheckResourceAttr(resourceName, "validate_before_create", "true"),
 				),
 			},
 			{
@@ -577,382 +2865,335 @@ func TestAccRDSClusterInstance_PerformanceInsightsEnabled_auroraMySQL1(t *testin
 				ImportStateVerifyIgnore: []string{
 					"apply_immediately",
 					"identifier_prefix",
+					"validate_before_create",
 				},
 			},
 		},
 	})
 }
 
-func TestAccRDSClusterInstance_PerformanceInsightsEnabled_auroraMySQL2(t *testing.T) {
+func TestAccRDSClusterInstance_finalSnapshotAurora(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping long-running test in short mode")
 	}
 
-	var dbInstance rds.DBInstance
-	resourceName := "aws_rds_cluster_instance.test"
 	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
-	engine := "aurora-mysql"
-	engineVersion := "5.7.mysql_aurora.2.04.2"
 
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:          func() { acctest.PreCheck(t); testAccPerformanceInsightsPreCheck(t, engine, engineVersion) },
+		PreCheck:          func() { acctest.PreCheck(t) },
 		ErrorCheck:        acctest.ErrorCheck(t, rds.EndpointsID),
 		ProviderFactories: acctest.ProviderFactories,
 		CheckDestroy:      testAccCheckClusterInstanceDestroy,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccClusterInstanceConfig_performanceInsightsEnabledAuroraMySQL2(rName, engine, engineVersion),
-				Check: resource.ComposeTestCheckFunc(
-					testAccCheckClusterInstanceExists(resourceName, &dbInstance),
-					resource.TestCheckResourceAttr(resourceName, "performance_insights_enabled", "true"),
-				),
-			},
-			{
-				ResourceName:      resourceName,
-				ImportState:       true,
-				ImportStateVerify: true,
-				ImportStateVerifyIgnore: []string{
-					"apply_immediately",
-					"identifier_prefix",
-				},
+				Config:      testAccClusterInstanceConfig_finalSnapshot(rName),
+				ExpectError: regexp.MustCompile(`skip_final_snapshot cannot be false for engine "aurora"`),
 			},
 		},
 	})
 }
 
-func TestAccRDSClusterInstance_PerformanceInsightsEnabled_auroraPostgresql(t *testing.T) {
+func TestAccRDSClusterInstance_backToBackUpdates(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping long-running test in short mode")
 	}
 
 	var dbInstance rds.DBInstance
-	resourceName := "aws_rds_cluster_instance.test"
 	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
-	engine := "aurora-postgresql"
+	resourceName := "aws_rds_cluster_instance.test"
 
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:          func() { acctest.PreCheck(t); testAccPerformanceInsightsDefaultVersionPreCheck(t, engine) },
+		PreCheck:          func() { acctest.PreCheck(t) },
 		ErrorCheck:        acctest.ErrorCheck(t, rds.EndpointsID),
 		ProviderFactories: acctest.ProviderFactories,
 		CheckDestroy:      testAccCheckClusterInstanceDestroy,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccClusterInstanceConfig_performanceInsightsEnabledAuroraPostgresql(rName, engine),
+				Config: testAccClusterInstanceConfig_backToBackUpdates(rName, "db.t3.small", 0),
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckClusterInstanceExists(resourceName, &dbInstance),
-					resource.TestCheckResourceAttr(resourceName, "performance_insights_enabled", "true"),
+					resource.TestCheckResourceAttr(resourceName, "instance_class", "db.t3.small"),
 				),
 			},
 			{
-				ResourceName:      resourceName,
-				ImportState:       true,
-				ImportStateVerify: true,
-				ImportStateVerifyIgnore: []string{
-					"apply_immediately",
-					"identifier_prefix",
-				},
+				Config: testAccClusterInstanceConfig_backToBackUpdates(rName, "db.t3.medium", 0),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckClusterInstanceExists(resourceName, &dbInstance),
+					resource.TestCheckResourceAttr(resourceName, "instance_class", "db.t3.medium"),
+				),
+			},
+			{
+				Config: testAccClusterInstanceConfig_backToBackUpdates(rName, "db.t3.medium", 3),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckClusterInstanceExists(resourceName, &dbInstance),
+					resource.TestCheckResourceAttr(resourceName, "promotion_tier", "3"),
+					resource.TestCheckResourceAttr(resourceName, "failover_priority", "3"),
+				),
 			},
 		},
 	})
 }
 
-func TestAccRDSClusterInstance_PerformanceInsightsKMSKeyID_auroraMySQL1(t *testing.T) {
+func TestAccRDSClusterInstance_deprecatedEngineError(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping long-running test in short mode")
 	}
 
-	var dbInstance rds.DBInstance
-	kmsKeyResourceName := "aws_kms_key.test"
-	resourceName := "aws_rds_cluster_instance.test"
 	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
-	engine := "aurora"
 
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:          func() { acctest.PreCheck(t); testAccPerformanceInsightsDefaultVersionPreCheck(t, engine) },
+		PreCheck:          func() { acctest.PreCheck(t) },
 		ErrorCheck:        acctest.ErrorCheck(t, rds.EndpointsID),
 		ProviderFactories: acctest.ProviderFactories,
 		CheckDestroy:      testAccCheckClusterInstanceDestroy,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccClusterInstanceConfig_performanceInsightsKMSKeyIDAuroraMySQL1(rName, engine),
-				Check: resource.ComposeTestCheckFunc(
-					testAccCheckClusterInstanceExists(resourceName, &dbInstance),
-					resource.TestCheckResourceAttr(resourceName, "performance_insights_enabled", "true"),
-					resource.TestCheckResourceAttrPair(resourceName, "performance_insights_kms_key_id", kmsKeyResourceName, "arn"),
-				),
-			},
-			{
-				ResourceName:      resourceName,
-				ImportState:       true,
-				ImportStateVerify: true,
-				ImportStateVerifyIgnore: []string{
-					"apply_immediately",
-					"identifier_prefix",
-				},
+				Config:      testAccClusterInstanceConfig_deprecatedEngineError(rName),
+				ExpectError: regexp.MustCompile(`engine "aurora" \(Aurora MySQL 5.6\) is deprecated`),
 			},
 		},
 	})
 }
 
-func TestAccRDSClusterInstance_PerformanceInsightsKMSKeyIDAuroraMySQL1_defaultKeyToCustomKey(t *testing.T) {
+func TestAccRDSClusterInstance_InstanceClass_ignoreChanges(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping long-running test in short mode")
 	}
 
 	var dbInstance rds.DBInstance
-	resourceName := "aws_rds_cluster_instance.test"
 	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
-	engine := "aurora"
+	resourceName := "aws_rds_cluster_instance.test"
 
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:          func() { acctest.PreCheck(t); testAccPerformanceInsightsDefaultVersionPreCheck(t, engine) },
+		PreCheck:          func() { acctest.PreCheck(t) },
 		ErrorCheck:        acctest.ErrorCheck(t, rds.EndpointsID),
 		ProviderFactories: acctest.ProviderFactories,
 		CheckDestroy:      testAccCheckClusterInstanceDestroy,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccClusterInstanceConfig_performanceInsightsEnabledAuroraMySQL1(rName, engine),
+				Config: testAccClusterInstanceConfig_instanceClassIgnoreChanges(rName),
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckClusterInstanceExists(resourceName, &dbInstance),
-					resource.TestCheckResourceAttr(resourceName, "performance_insights_enabled", "true"),
+					resource.TestCheckResourceAttr(resourceName, "instance_class", "db.t3.small"),
+					testAccCheckClusterInstanceModifyInstanceClass(&dbInstance, "db.t3.medium"),
 				),
 			},
 			{
-				ResourceName:      resourceName,
-				ImportState:       true,
-				ImportStateVerify: true,
-				ImportStateVerifyIgnore: []string{
-					"apply_immediately",
-					"identifier_prefix",
-				},
-			},
-			{
-				Config:      testAccClusterInstanceConfig_performanceInsightsKMSKeyIDAuroraMySQL1(rName, engine),
-				ExpectError: regexp.MustCompile(`InvalidParameterCombination: You .* change your Performance Insights KMS key`),
+				Config: testAccClusterInstanceConfig_instanceClassIgnoreChanges(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckClusterInstanceExists(resourceName, &dbInstance),
+					resource.TestCheckResourceAttr(resourceName, "instance_class", "db.t3.medium"),
+				),
 			},
 		},
 	})
 }
 
-func TestAccRDSClusterInstance_PerformanceInsightsKMSKeyID_auroraMySQL2(t *testing.T) {
+func TestAccRDSClusterInstance_autoMinorVersionUpgradeWithEngineVersion(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping long-running test in short mode")
 	}
 
 	var dbInstance rds.DBInstance
-	kmsKeyResourceName := "aws_kms_key.test"
-	resourceName := "aws_rds_cluster_instance.test"
 	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
-	engine := "aurora-mysql"
-	engineVersion := "5.7.mysql_aurora.2.04.2"
+	resourceName := "aws_rds_cluster_instance.test"
 
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:          func() { acctest.PreCheck(t); testAccPerformanceInsightsPreCheck(t, engine, engineVersion) },
+		PreCheck:          func() { acctest.PreCheck(t) },
 		ErrorCheck:        acctest.ErrorCheck(t, rds.EndpointsID),
 		ProviderFactories: acctest.ProviderFactories,
 		CheckDestroy:      testAccCheckClusterInstanceDestroy,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccClusterInstanceConfig_performanceInsightsKMSKeyIDAuroraMySQL2(rName, engine, engineVersion),
+				Config: testAccClusterInstanceConfig_autoMinorVersionUpgradeWithEngineVersion(rName, "5.7.mysql_aurora.2.10.1", false),
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckClusterInstanceExists(resourceName, &dbInstance),
-					resource.TestCheckResourceAttr(resourceName, "performance_insights_enabled", "true"),
-					resource.TestCheckResourceAttrPair(resourceName, "performance_insights_kms_key_id", kmsKeyResourceName, "arn"),
+					resource.TestCheckResourceAttr(resourceName, "auto_minor_version_upgrade", "false"),
+					resource.TestCheckResourceAttr(resourceName, "engine_version_actual", "5.7.mysql_aurora.2.10.1"),
 				),
 			},
 			{
-				ResourceName:      resourceName,
-				ImportState:       true,
-				ImportStateVerify: true,
-				ImportStateVerifyIgnore: []string{
-					"apply_immediately",
-					"identifier_prefix",
-				},
+				Config: testAccClusterInstanceConfig_autoMinorVersionUpgradeWithEngineVersion(rName, "5.7.mysql_aurora.2.10.2", true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckClusterInstanceExists(resourceName, &dbInstance),
+					resource.TestCheckResourceAttr(resourceName, "auto_minor_version_upgrade", "true"),
+					resource.TestCheckResourceAttr(resourceName, "engine_version", "5.7.mysql_aurora.2.10.2"),
+					resource.TestCheckResourceAttr(resourceName, "engine_version_actual", "5.7.mysql_aurora.2.10.2"),
+				),
 			},
 		},
 	})
 }
 
-func TestAccRDSClusterInstance_PerformanceInsightsKMSKeyIDAuroraMySQL2_defaultKeyToCustomKey(t *testing.T) {
+func TestAccRDSClusterInstance_autoMinorVersionUpgradeWithInstanceEngineVersionOnly(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping long-running test in short mode")
 	}
 
 	var dbInstance rds.DBInstance
-	resourceName := "aws_rds_cluster_instance.test"
 	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
-	engine := "aurora-mysql"
-	engineVersion := "5.7.mysql_aurora.2.04.2"
+	resourceName := "aws_rds_cluster_instance.test"
 
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:          func() { acctest.PreCheck(t); testAccPerformanceInsightsPreCheck(t, engine, engineVersion) },
+		PreCheck:          func() { acctest.PreCheck(t) },
 		ErrorCheck:        acctest.ErrorCheck(t, rds.EndpointsID),
 		ProviderFactories: acctest.ProviderFactories,
 		CheckDestroy:      testAccCheckClusterInstanceDestroy,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccClusterInstanceConfig_performanceInsightsEnabledAuroraMySQL2(rName, engine, engineVersion),
+				Config: testAccClusterInstanceConfig_autoMinorVersionUpgradeWithInstanceEngineVersion(rName, "5.7.mysql_aurora.2.10.1", false),
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckClusterInstanceExists(resourceName, &dbInstance),
-					resource.TestCheckResourceAttr(resourceName, "performance_insights_enabled", "true"),
+					resource.TestCheckResourceAttr(resourceName, "auto_minor_version_upgrade", "false"),
+					resource.TestCheckResourceAttr(resourceName, "engine_version_actual", "5.7.mysql_aurora.2.10.1"),
 				),
 			},
 			{
-				ResourceName:      resourceName,
-				ImportState:       true,
-				ImportStateVerify: true,
-				ImportStateVerifyIgnore: []string{
-					"apply_immediately",
-					"identifier_prefix",
-				},
-			},
-			{
-				Config:      testAccClusterInstanceConfig_performanceInsightsKMSKeyIDAuroraMySQL2(rName, engine, engineVersion),
-				ExpectError: regexp.MustCompile(`InvalidParameterCombination: You .* change your Performance Insights KMS key`),
+				Config:      testAccClusterInstanceConfig_autoMinorVersionUpgradeWithInstanceEngineVersion(rName, "5.7.mysql_aurora.2.10.2", true),
+				ExpectError: regexp.MustCompile(`isn't being upgraded to engine_version \(5\.7\.mysql_aurora\.2\.10\.2\)`),
 			},
 		},
 	})
 }
 
-func TestAccRDSClusterInstance_performanceInsightsRetentionPeriod(t *testing.T) {
+func TestAccRDSClusterInstance_defaultAndIgnoreTags(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping long-running test in short mode")
 	}
 
 	var dbInstance rds.DBInstance
-	resourceName := "aws_rds_cluster_instance.test"
 	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	resourceName := "aws_rds_cluster_instance.test"
 
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:          func() { acctest.PreCheck(t); testAccPerformanceInsightsDefaultVersionPreCheck(t, "aurora") },
+		PreCheck:          func() { acctest.PreCheck(t) },
 		ErrorCheck:        acctest.ErrorCheck(t, rds.EndpointsID),
 		ProviderFactories: acctest.ProviderFactories,
-		CheckDestroy:      testAccCheckClusterDestroy,
+		CheckDestroy:      testAccCheckClusterInstanceDestroy,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccClusterInstanceConfig_performanceInsightsRetentionPeriod(rName, 731),
+				Config: acctest.ConfigCompose(
+					acctest.ConfigDefaultAndIgnoreTagsKeyPrefixes1("defaultkey1", "defaultvalue1", "defaultkey"),
+					testAccClusterInstanceConfig_tags1(rName, "key1", "value1"),
+				),
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckClusterInstanceExists(resourceName, &dbInstance),
-					resource.TestCheckResourceAttr(resourceName, "performance_insights_enabled", "true"),
-					resource.TestCheckResourceAttr(resourceName, "performance_insights_retention_period", "731"),
+					resource.TestCheckResourceAttr(resourceName, "tags.%", "1"),
+					resource.TestCheckResourceAttr(resourceName, "tags.key1", "value1"),
+					resource.TestCheckResourceAttr(resourceName, "tags_all.%", "1"),
+					resource.TestCheckResourceAttr(resourceName, "tags_all.key1", "value1"),
+					testAccCheckClusterInstanceTags(&dbInstance, map[string]string{"defaultkey1": "defaultvalue1", "key1": "value1"}),
+					testAccCheckClusterInstanceUpdateTags(&dbInstance, nil, map[string]string{"defaultkey1": "externalvalue1"}),
 				),
 			},
 			{
-				ResourceName:      resourceName,
-				ImportState:       true,
-				ImportStateVerify: true,
-				ImportStateVerifyIgnore: []string{
-					"apply_immediately",
-					"identifier_prefix",
-				},
+				Config: acctest.ConfigCompose(
+					acctest.ConfigDefaultAndIgnoreTagsKeyPrefixes1("defaultkey1", "defaultvalue1", "defaultkey"),
+					testAccClusterInstanceConfig_tags1(rName, "key1", "value1"),
+				),
+				PlanOnly: true,
 			},
 			{
-				Config: testAccClusterInstanceConfig_performanceInsightsRetentionPeriod(rName, 7),
-				Check: resource.ComposeTestCheckFunc(
-					testAccCheckClusterInstanceExists(resourceName, &dbInstance),
-					resource.TestCheckResourceAttr(resourceName, "performance_insights_enabled", "true"),
-					resource.TestCheckResourceAttr(resourceName, "performance_insights_retention_period", "7"),
+				Config: acctest.ConfigCompose(
+					acctest.ConfigDefaultAndIgnoreTagsKeys1("defaultkey1", "defaultvalue1"),
+					testAccClusterInstanceConfig_tags1(rName, "key1", "value1"),
 				),
+				PlanOnly: true,
 			},
 			{
-				Config: testAccClusterInstanceConfig_performanceInsightsRetentionPeriod(rName, 155),
+				Config: acctest.ConfigCompose(
+					acctest.ConfigDefaultAndIgnoreTagsKeys1("defaultkey1", "defaultvalue1"),
+					testAccClusterInstanceConfig_tags1(rName, "key1", "value2"),
+				),
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckClusterInstanceExists(resourceName, &dbInstance),
-					resource.TestCheckResourceAttr(resourceName, "performance_insights_enabled", "true"),
-					resource.TestCheckResourceAttr(resourceName, "performance_insights_retention_period", "155"),
+					resource.TestCheckResourceAttr(resourceName, "tags.%", "1"),
+					resource.TestCheckResourceAttr(resourceName, "tags.key1", "value2"),
+					resource.TestCheckResourceAttr(resourceName, "tags_all.%", "1"),
+					resource.TestCheckResourceAttr(resourceName, "tags_all.key1", "value2"),
+					testAccCheckClusterInstanceTags(&dbInstance, map[string]string{"defaultkey1": "externalvalue1", "key1": "value2"}),
 				),
 			},
 		},
 	})
 }
 
-func TestAccRDSClusterInstance_PerformanceInsightsKMSKeyID_auroraPostgresql(t *testing.T) {
+func TestAccRDSClusterInstance_rebootTrigger(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping long-running test in short mode")
-	}
-
-	var dbInstance rds.DBInstance
-	kmsKeyResourceName := "aws_kms_key.test"
-	resourceName := "aws_rds_cluster_instance.test"
+	}
+
+	var dbInstance1, dbInstance2 rds.DBInstance
+	var rebootTime time.Time
 	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
-	engine := "aurora-postgresql"
+	resourceName := "aws_rds_cluster_instance.test"
 
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:          func() { acctest.PreCheck(t); testAccPerformanceInsightsDefaultVersionPreCheck(t, engine) },
+		PreCheck:          func() { acctest.PreCheck(t) },
 		ErrorCheck:        acctest.ErrorCheck(t, rds.EndpointsID),
 		ProviderFactories: acctest.ProviderFactories,
 		CheckDestroy:      testAccCheckClusterInstanceDestroy,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccClusterInstanceConfig_performanceInsightsKMSKeyIDAuroraPostgresql(rName, engine),
+				Config: testAccClusterInstanceConfig_rebootTrigger(rName, "1"),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckClusterInstanceExists(resourceName, &dbInstance),
-					resource.TestCheckResourceAttr(resourceName, "performance_insights_enabled", "true"),
-					resource.TestCheckResourceAttrPair(resourceName, "performance_insights_kms_key_id", kmsKeyResourceName, "arn"),
+					testAccCheckClusterInstanceExists(resourceName, &dbInstance1),
+					resource.TestCheckResourceAttr(resourceName, "reboot_trigger", "1"),
+					func(*terraform.State) error {
+						rebootTime = time.Now()
+						return nil
+					},
 				),
 			},
 			{
-				ResourceName:      resourceName,
-				ImportState:       true,
-				ImportStateVerify: true,
-				ImportStateVerifyIgnore: []string{
-					"apply_immediately",
-					"identifier_prefix",
-				},
+				Config: testAccClusterInstanceConfig_rebootTrigger(rName, "2"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckClusterInstanceExists(resourceName, &dbInstance2),
+					testAccCheckClusterInstanceNotRecreated(&dbInstance1, &dbInstance2),
+					testAccCheckClusterInstanceRebootedSince(&dbInstance2, &rebootTime),
+					resource.TestCheckResourceAttr(resourceName, "reboot_trigger", "2"),
+				),
 			},
 		},
 	})
 }
 
-func TestAccRDSClusterInstance_PerformanceInsightsKMSKeyIDAuroraPostgresql_defaultKeyToCustomKey(t *testing.T) {
+func TestAccRDSClusterInstance_parallelReaders(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping long-running test in short mode")
 	}
 
 	var dbInstance rds.DBInstance
-	resourceName := "aws_rds_cluster_instance.test"
 	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
-	engine := "aurora-postgresql"
+	resourceName := "aws_rds_cluster_instance.test"
 
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:          func() { acctest.PreCheck(t); testAccPerformanceInsightsDefaultVersionPreCheck(t, engine) },
+		PreCheck:          func() { acctest.PreCheck(t) },
 		ErrorCheck:        acctest.ErrorCheck(t, rds.EndpointsID),
 		ProviderFactories: acctest.ProviderFactories,
 		CheckDestroy:      testAccCheckClusterInstanceDestroy,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccClusterInstanceConfig_performanceInsightsEnabledAuroraPostgresql(rName, engine),
+				Config: testAccClusterInstanceConfig_parallelReaders(rName, 4),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckClusterInstanceExists(resourceName, &dbInstance),
-					resource.TestCheckResourceAttr(resourceName, "performance_insights_enabled", "true"),
+					testAccCheckClusterInstanceExists(resourceName+".0", &dbInstance),
+					testAccCheckClusterInstanceExists(resourceName+".1", &dbInstance),
+					testAccCheckClusterInstanceExists(resourceName+".2", &dbInstance),
+					testAccCheckClusterInstanceExists(resourceName+".3", &dbInstance),
 				),
 			},
-			{
-				ResourceName:      resourceName,
-				ImportState:       true,
-				ImportStateVerify: true,
-				ImportStateVerifyIgnore: []string{
-					"apply_immediately",
-					"identifier_prefix",
-				},
-			},
-			{
-				Config:      testAccClusterInstanceConfig_performanceInsightsKMSKeyIDAuroraPostgresql(rName, engine),
-				ExpectError: regexp.MustCompile(`InvalidParameterCombination: You .* change your Performance Insights KMS key`),
-			},
 		},
 	})
 }
 
-func TestAccRDSClusterInstance_caCertificateIdentifier(t *testing.T) {
+func TestAccRDSClusterInstance_dbSubnetGroupName(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping long-running test in short mode")
 	}
 
-	var dbInstance rds.DBInstance
+	var dbInstance1, dbInstance2 rds.DBInstance
 	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
 	resourceName := "aws_rds_cluster_instance.test"
-	dataSourceName := "data.aws_rds_certificate.latest"
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:          func() { acctest.PreCheck(t) },
@@ -961,20 +3202,23 @@ func TestAccRDSClusterInstance_caCertificateIdentifier(t *testing.T) {
 		CheckDestroy:      testAccCheckClusterInstanceDestroy,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccClusterInstanceConfig_caCertificateID(rName),
+				Config: testAccClusterInstanceConfig_dbSubnetGroupName(rName, 0),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckClusterInstanceExists(resourceName, &dbInstance),
-					resource.TestCheckResourceAttrPair(resourceName, "ca_cert_identifier", dataSourceName, "id"),
+					testAccCheckClusterInstanceExists(resourceName, &dbInstance1),
+					resource.TestCheckResourceAttrPair(resourceName, "db_subnet_group_name", "aws_db_subnet_group.test.0", "name"),
+					resource.TestCheckResourceAttrPair(resourceName, "db_subnet_group_arn", "aws_db_subnet_group.test.0", "arn"),
+					resource.TestCheckResourceAttrPair(resourceName, "subnet_ids.#", "aws_db_subnet_group.test.0", "subnet_ids.#"),
 				),
 			},
 			{
-				ResourceName:      resourceName,
-				ImportState:       true,
-				ImportStateVerify: true,
-				ImportStateVerifyIgnore: []string{
-					"apply_immediately",
-					"identifier_prefix",
-				},
+				Config: testAccClusterInstanceConfig_dbSubnetGroupName(rName, 1),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckClusterInstanceExists(resourceName, &dbInstance2),
+					testAccCheckClusterInstanceRecreated(&dbInstance1, &dbInstance2),
+					resource.TestCheckResourceAttrPair(resourceName, "db_subnet_group_name", "aws_db_subnet_group.test.1", "name"),
+					resource.TestCheckResourceAttrPair(resourceName, "db_subnet_group_arn", "aws_db_subnet_group.test.1", "arn"),
+					resource.TestCheckResourceAttrPair(resourceName, "subnet_ids.#", "aws_db_subnet_group.test.1", "subnet_ids.#"),
+				),
 			},
 		},
 	})
@@ -1050,6 +3294,47 @@ func testAccCheckClusterInstanceAttributes(v *rds.DBInstance) resource.TestCheck
 	}
 }
 
+func testAccCheckClusterInstanceTags(v *rds.DBInstance, expected map[string]string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		conn := acctest.Provider.Meta().(*conns.AWSClient).RDSConn
+
+		tags, err := tfrds.ListTags(conn, aws.StringValue(v.DBInstanceArn))
+
+		if err != nil {
+			return err
+		}
+
+		if got := tags.IgnoreAWS().Map(); !reflect.DeepEqual(got, expected) {
+			return fmt.Errorf("RDS Cluster Instance (%s) tags: got %v, expected %v", aws.StringValue(v.DBInstanceIdentifier), got, expected)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckClusterInstanceUpdateTags(v *rds.DBInstance, oldTags, newTags map[string]string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		conn := acctest.Provider.Meta().(*conns.AWSClient).RDSConn
+
+		return tfrds.UpdateTags(conn, aws.StringValue(v.DBInstanceArn), oldTags, newTags)
+	}
+}
+
+func testAccCheckClusterInstanceCACertificateIdentifier(n string, v *rds.DBInstance) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if got, expected := rs.Primary.Attributes["ca_cert_identifier"], aws.StringValue(v.CACertificateIdentifier); got != expected {
+			return fmt.Errorf("RDS Cluster Instance (%s) ca_cert_identifier: got %q, expected %q", rs.Primary.ID, got, expected)
+		}
+
+		return nil
+	}
+}
+
 func testAccCheckClusterInstanceExists(n string, v *rds.DBInstance) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
@@ -1075,6 +3360,106 @@ func testAccCheckClusterInstanceExists(n string, v *rds.DBInstance) resource.Tes
 	}
 }
 
+// testAccCheckClusterInstanceDeleting verifies that the instance has not yet
+// finished deleting, i.e. that the delete did not wait for it to disappear.
+func testAccCheckClusterInstanceDeleting(v *rds.DBInstance) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		conn := acctest.Provider.Meta().(*conns.AWSClient).RDSConn
+
+		output, err := tfrds.FindDBInstanceByID(conn, aws.StringValue(v.DBInstanceIdentifier))
+
+		if tfresource.NotFound(err) {
+			return fmt.Errorf("RDS Cluster Instance %s no longer exists, expected it to still be deleting", aws.StringValue(v.DBInstanceIdentifier))
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if status := aws.StringValue(output.DBInstanceStatus); status != tfrds.InstanceStatusDeleting {
+			return fmt.Errorf("RDS Cluster Instance %s status = %s, expected %s", aws.StringValue(v.DBInstanceIdentifier), status, tfrds.InstanceStatusDeleting)
+		}
+
+		return nil
+	}
+}
+
+// testAccCheckClusterInstanceModifyInstanceClass changes the instance class outside of Terraform.
+func testAccCheckClusterInstanceModifyInstanceClass(v *rds.DBInstance, instanceClass string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		conn := acctest.Provider.Meta().(*conns.AWSClient).RDSConn
+		id := aws.StringValue(v.DBInstanceIdentifier)
+
+		_, err := conn.ModifyDBInstance(&rds.ModifyDBInstanceInput{
+			ApplyImmediately:     aws.Bool(true),
+			DBInstanceClass:      aws.String(instanceClass),
+			DBInstanceIdentifier: aws.String(id),
+		})
+
+		if err != nil {
+			return err
+		}
+
+		return resource.Retry(30*time.Minute, func() *resource.RetryError {
+			output, err := tfrds.FindDBInstanceByID(conn, id)
+
+			if err != nil {
+				return resource.NonRetryableError(err)
+			}
+
+			if aws.StringValue(output.DBInstanceClass) != instanceClass || aws.StringValue(output.DBInstanceStatus) != tfrds.InstanceStatusAvailable {
+				return resource.RetryableError(fmt.Errorf("RDS Cluster Instance %s is still being modified", id))
+			}
+
+			return nil
+		})
+	}
+}
+
+func testAccCheckClusterInstanceRecreated(before, after *rds.DBInstance) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if aws.StringValue(before.DbiResourceId) == aws.StringValue(after.DbiResourceId) {
+			return fmt.Errorf("RDS Cluster Instance (%s) not recreated", aws.StringValue(before.DBInstanceIdentifier))
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckClusterInstanceNotRecreated(before, after *rds.DBInstance) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if aws.StringValue(before.DbiResourceId) != aws.StringValue(after.DbiResourceId) {
+			return fmt.Errorf("RDS Cluster Instance (%s) recreated", aws.StringValue(before.DBInstanceIdentifier))
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckClusterInstanceRebootedSince(v *rds.DBInstance, since *time.Time) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		conn := acctest.Provider.Meta().(*conns.AWSClient).RDSConn
+
+		output, err := conn.DescribeEvents(&rds.DescribeEventsInput{
+			SourceIdentifier: v.DBInstanceIdentifier,
+			SourceType:       aws.String(rds.SourceTypeDbInstance),
+			StartTime:        since,
+		})
+
+		if err != nil {
+			return err
+		}
+
+		for _, event := range output.Events {
+			if strings.Contains(aws.StringValue(event.Message), "restarted") {
+				return nil
+			}
+		}
+
+		return fmt.Errorf("RDS Cluster Instance (%s) not rebooted since %s", aws.StringValue(v.DBInstanceIdentifier), since)
+	}
+}
+
 func testAccCheckClusterInstanceDestroy(s *terraform.State) error {
 	conn := acctest.Provider.Meta().(*conns.AWSClient).RDSConn
 
@@ -1099,6 +3484,18 @@ func testAccCheckClusterInstanceDestroy(s *terraform.State) error {
 	return nil
 }
 
+// testAccClusterInstanceConfig_baseCluster is a cluster with the default engine, to which configurations add instances.
+func testAccClusterInstanceConfig_baseCluster(rName string) string {
+	return fmt.Sprintf(`
+resource "aws_rds_cluster" "test" {
+  cluster_identifier  = %[1]q
+  master_username     = "foo"
+  master_password     = "mustbeeightcharacters"
+  skip_final_snapshot = true
+}
+`, rName)
+}
+
 // Add some random to the name, to avoid collision
 func testAccClusterInstanceConfig_basic(n int) string {
 	return acctest.ConfigCompose(acctest.ConfigAvailableAZsNoOptIn(), fmt.Sprintf(`
@@ -1146,6 +3543,31 @@ resource "aws_db_parameter_group" "bar" {
 `, n))
 }
 
+func testAccClusterInstanceConfig_importRoundTrip(rName string) string {
+	return acctest.ConfigCompose(testAccClusterInstanceConfig_baseCluster(rName), `
+data "aws_rds_orderable_db_instance" "test" {
+  engine                     = aws_rds_cluster.test.engine
+  engine_version             = aws_rds_cluster.test.engine_version
+  preferred_instance_classes = ["db.t3.small", "db.t2.small", "db.t3.medium"]
+}
+
+resource "aws_rds_cluster_instance" "test" {
+  apply_immediately            = true
+  auto_minor_version_upgrade   = false
+  cluster_identifier           = aws_rds_cluster.test.id
+  copy_tags_to_snapshot        = true
+  identifier_prefix            = "tf-acc-test-import-"
+  instance_class               = data.aws_rds_orderable_db_instance.test.instance_class
+  preferred_maintenance_window = "sun:07:00-sun:08:00"
+  promotion_tier               = 2
+
+  tags = {
+    Name = "tf-acc-test-import"
+  }
+}
+`)
+}
+
 func testAccClusterInstanceConfig_modified(n int) string {
 	return acctest.ConfigCompose(acctest.ConfigAvailableAZsNoOptIn(), fmt.Sprintf(`
 resource "aws_rds_cluster" "default" {
@@ -1353,6 +3775,15 @@ resource "aws_db_subnet_group" "test" {
 `, n))
 }
 
+func testAccClusterInstanceConfig_providerDefaultIdentifierPrefix(prefix string) string {
+	//lintignore:AT004
+	return fmt.Sprintf(`
+provider "aws" {
+  default_identifier_prefix = %[1]q
+}
+`, prefix)
+}
+
 func testAccClusterInstanceConfig_kmsKey(n int) string {
 	return acctest.ConfigCompose(acctest.ConfigAvailableAZsNoOptIn(), fmt.Sprintf(`
 resource "aws_kms_key" "foo" {
@@ -1415,14 +3846,93 @@ resource "aws_db_parameter_group" "bar" {
     apply_method = "pending-reboot"
   }
 
-  tags = {
-    foo = "bar"
-  }
+  tags = {
+    foo = "bar"
+  }
+}
+`, n))
+}
+
+func testAccClusterInstanceConfig_monitoringInterval(rName string, monitoringInterval int) string {
+	return fmt.Sprintf(`
+data "aws_partition" "current" {}
+
+resource "aws_iam_role" "test" {
+  name = %[1]q
+
+  assume_role_policy = <<EOF
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Sid": "",
+      "Effect": "Allow",
+      "Principal": {
+        "Service": "monitoring.rds.amazonaws.com"
+      },
+      "Action": "sts:AssumeRole"
+    }
+  ]
+}
+EOF
+}
+
+resource "aws_iam_role_policy_attachment" "test" {
+  policy_arn = "arn:${data.aws_partition.current.partition}:iam::aws:policy/service-role/AmazonRDSEnhancedMonitoringRole"
+  role       = aws_iam_role.test.name
+}
+
+resource "aws_rds_cluster" "test" {
+  cluster_identifier  = %[1]q
+  database_name       = "mydb"
+  master_username     = "foo"
+  master_password     = "mustbeeightcharacters"
+  skip_final_snapshot = true
+}
+
+data "aws_rds_orderable_db_instance" "test" {
+  engine                     = aws_rds_cluster.test.engine
+  engine_version             = aws_rds_cluster.test.engine_version
+  preferred_instance_classes = ["db.t3.small", "db.t2.small", "db.t3.medium"]
+}
+
+resource "aws_rds_cluster_instance" "test" {
+  depends_on = [aws_iam_role_policy_attachment.test]
+
+  cluster_identifier  = aws_rds_cluster.test.id
+  identifier          = %[1]q
+  instance_class      = data.aws_rds_orderable_db_instance.test.instance_class
+  monitoring_interval = %[2]d
+  monitoring_role_arn = aws_iam_role.test.arn
+}
+`, rName, monitoringInterval)
+}
+
+func testAccClusterInstanceConfig_monitoringRoleARNRemoved(rName string) string {
+	return fmt.Sprintf(`
+resource "aws_rds_cluster" "test" {
+  cluster_identifier  = %[1]q
+  database_name       = "mydb"
+  master_username     = "foo"
+  master_password     = "mustbeeightcharaters"
+  skip_final_snapshot = true
+}
+
+data "aws_rds_orderable_db_instance" "test" {
+  engine                     = aws_rds_cluster.test.engine
+  engine_version             = aws_rds_cluster.test.engine_version
+  preferred_instance_classes = ["db.t3.small", "db.t2.small", "db.t3.medium"]
+}
+
+resource "aws_rds_cluster_instance" "test" {
+  cluster_identifier = aws_rds_cluster.test.id
+  identifier         = %[1]q
+  instance_class     = data.aws_rds_orderable_db_instance.test.instance_class
 }
-`, n))
+`, rName)
 }
 
-func testAccClusterInstanceConfig_monitoringInterval(rName string, monitoringInterval int) string {
+func testAccClusterInstanceConfig_monitoringRoleARN(rName string) string {
 	return fmt.Sprintf(`
 data "aws_partition" "current" {}
 
@@ -1455,7 +3965,7 @@ resource "aws_rds_cluster" "test" {
   cluster_identifier  = %[1]q
   database_name       = "mydb"
   master_username     = "foo"
-  master_password     = "mustbeeightcharacters"
+  master_password     = "mustbeeightcharaters"
   skip_final_snapshot = true
 }
 
@@ -1471,37 +3981,13 @@ resource "aws_rds_cluster_instance" "test" {
   cluster_identifier  = aws_rds_cluster.test.id
   identifier          = %[1]q
   instance_class      = data.aws_rds_orderable_db_instance.test.instance_class
-  monitoring_interval = %[2]d
+  monitoring_interval = 5
   monitoring_role_arn = aws_iam_role.test.arn
 }
-`, rName, monitoringInterval)
-}
-
-func testAccClusterInstanceConfig_monitoringRoleARNRemoved(rName string) string {
-	return fmt.Sprintf(`
-resource "aws_rds_cluster" "test" {
-  cluster_identifier  = %[1]q
-  database_name       = "mydb"
-  master_username     = "foo"
-  master_password     = "mustbeeightcharaters"
-  skip_final_snapshot = true
-}
-
-data "aws_rds_orderable_db_instance" "test" {
-  engine                     = aws_rds_cluster.test.engine
-  engine_version             = aws_rds_cluster.test.engine_version
-  preferred_instance_classes = ["db.t3.small", "db.t2.small", "db.t3.medium"]
-}
-
-resource "aws_rds_cluster_instance" "test" {
-  cluster_identifier = aws_rds_cluster.test.id
-  identifier         = %[1]q
-  instance_class     = data.aws_rds_orderable_db_instance.test.instance_class
-}
 `, rName)
 }
 
-func testAccClusterInstanceConfig_monitoringRoleARN(rName string) string {
+func testAccClusterInstanceConfig_monitoringDisabled(rName string) string {
 	return fmt.Sprintf(`
 data "aws_partition" "current" {}
 
@@ -1550,8 +4036,8 @@ resource "aws_rds_cluster_instance" "test" {
   cluster_identifier  = aws_rds_cluster.test.id
   identifier          = %[1]q
   instance_class      = data.aws_rds_orderable_db_instance.test.instance_class
-  monitoring_interval = 5
-  monitoring_role_arn = aws_iam_role.test.arn
+  apply_immediately   = true
+  monitoring_interval = 0
 }
 `, rName)
 }
@@ -1772,6 +4258,41 @@ resource "aws_rds_cluster_instance" "test" {
 `, rName, performanceInsightsRetentionPeriod)
 }
 
+func testAccClusterInstanceConfig_performanceInsightsRetentionPeriodKMSKeyID(rName string, performanceInsightsRetentionPeriod int) string {
+	return fmt.Sprintf(`
+resource "aws_kms_key" "test" {
+  deletion_window_in_days = 7
+}
+
+resource "aws_rds_cluster" "test" {
+  cluster_identifier  = %[1]q
+  database_name       = "mydb"
+  engine              = "aurora"
+  master_password     = "mustbeeightcharacters"
+  master_username     = "foo"
+  skip_final_snapshot = true
+}
+
+data "aws_rds_orderable_db_instance" "test" {
+  engine                        = aws_rds_cluster.test.engine
+  engine_version                = aws_rds_cluster.test.engine_version
+  supports_performance_insights = true
+  preferred_instance_classes    = ["db.t3.medium", "db.r5.large", "db.r4.large"]
+}
+
+resource "aws_rds_cluster_instance" "test" {
+  apply_immediately                     = true
+  cluster_identifier                    = aws_rds_cluster.test.id
+  engine                                = aws_rds_cluster.test.engine
+  identifier                            = %[1]q
+  instance_class                        = data.aws_rds_orderable_db_instance.test.instance_class
+  performance_insights_enabled          = true
+  performance_insights_kms_key_id       = aws_kms_key.test.arn
+  performance_insights_retention_period = %[2]d
+}
+`, rName, performanceInsightsRetentionPeriod)
+}
+
 func testAccClusterInstanceConfig_publiclyAccessible(rName string, publiclyAccessible bool) string {
 	return fmt.Sprintf(`
 resource "aws_rds_cluster" "test" {
@@ -1853,6 +4374,286 @@ resource "aws_rds_cluster_instance" "test" {
   identifier         = %[1]q
   instance_class     = data.aws_rds_orderable_db_instance.test.instance_class
   ca_cert_identifier = data.aws_rds_certificate.latest.id
+
+  lookup_ca_cert_expiring_soon = true
 }
 `, rName)
 }
+
+func testAccClusterInstanceConfig_caCertificateIDUnset(rName string) string {
+	return acctest.ConfigCompose(testAccClusterInstanceConfig_baseCluster(rName), fmt.Sprintf(`
+data "aws_rds_orderable_db_instance" "test" {
+  engine                     = aws_rds_cluster.test.engine
+  engine_version             = aws_rds_cluster.test.engine_version
+  preferred_instance_classes = ["db.t3.small", "db.t2.small", "db.t3.medium"]
+}
+
+resource "aws_rds_cluster_instance" "test" {
+  cluster_identifier = aws_rds_cluster.test.id
+  identifier         = %[1]q
+  instance_class     = data.aws_rds_orderable_db_instance.test.instance_class
+}
+`, rName))
+}
+
+// testAccClusterInstanceConfig_skipDeleteWaitRemoved is testAccClusterInstanceConfig_skipDeleteWait without the instance.
+func testAccClusterInstanceConfig_skipDeleteWaitRemoved(rName string) string {
+	return acctest.ConfigCompose(testAccClusterInstanceConfig_baseCluster(rName))
+}
+
+func testAccClusterInstanceConfig_skipDeleteWait(rName string) string {
+	return acctest.ConfigCompose(testAccClusterInstanceConfig_baseCluster(rName), fmt.Sprintf(`
+data "aws_rds_orderable_db_instance" "test" {
+  engine                     = aws_rds_cluster.test.engine
+  engine_version             = aws_rds_cluster.test.engine_version
+  preferred_instance_classes = ["db.t3.small", "db.t2.small", "db.t3.medium"]
+}
+
+resource "aws_rds_cluster_instance" "test" {
+  cluster_identifier = aws_rds_cluster.test.id
+  identifier         = %[1]q
+  instance_class     = data.aws_rds_orderable_db_instance.test.instance_class
+  skip_delete_wait   = true
+}
+`, rName))
+}
+
+func testAccClusterInstanceConfig_validateBeforeCreate(rName string) string {
+	return acctest.ConfigCompose(testAccClusterInstanceConfig_baseCluster(rName), fmt.Sprintf(`
+data "aws_rds_orderable_db_instance" "test" {
+  engine                     = aws_rds_cluster.test.engine
+  engine_version             = aws_rds_cluster.test.engine_version
+  preferred_instance_classes = ["db.t3.small", "db.t2.small", "db.t3.medium"]
+}
+
+resource "aws_rds_cluster_instance" "test" {
+  cluster_identifier     = aws_rds_cluster.test.id
+  identifier             = %[1]q
+  instance_class         = data.aws_rds_orderable_db_instance.test.instance_class
+  validate_before_create = true
+}
+`, rName))
+}
+
+func testAccClusterInstanceConfig_validateBeforeCreateInstanceClass(rName, instanceClass string) string {
+	return acctest.ConfigCompose(testAccClusterInstanceConfig_baseCluster(rName), fmt.Sprintf(`
+resource "aws_rds_cluster_instance" "test" {
+  cluster_identifier     = aws_rds_cluster.test.id
+  identifier             = %[1]q
+  instance_class         = %[2]q
+  validate_before_create = true
+}
+`, rName, instanceClass))
+}
+
+func testAccClusterInstanceConfig_finalSnapshot(rName string) string {
+	return acctest.ConfigCompose(testAccClusterInstanceConfig_baseCluster(rName), fmt.Sprintf(`
+data "aws_rds_orderable_db_instance" "test" {
+  engine                     = aws_rds_cluster.test.engine
+  engine_version             = aws_rds_cluster.test.engine_version
+  preferred_instance_classes = ["db.t3.small", "db.t2.small", "db.t3.medium"]
+}
+
+resource "aws_rds_cluster_instance" "test" {
+  cluster_identifier        = aws_rds_cluster.test.id
+  identifier                = %[1]q
+  instance_class            = data.aws_rds_orderable_db_instance.test.instance_class
+  skip_final_snapshot       = false
+  final_snapshot_identifier = %[1]q
+}
+`, rName))
+}
+
+func testAccClusterInstanceConfig_backToBackUpdates(rName, instanceClass string, promotionTier int) string {
+	return acctest.ConfigCompose(testAccClusterInstanceConfig_baseCluster(rName), fmt.Sprintf(`
+resource "aws_rds_cluster_instance" "test" {
+  apply_immediately  = true
+  cluster_identifier = aws_rds_cluster.test.id
+  identifier         = %[1]q
+  instance_class     = %[2]q
+  promotion_tier     = %[3]d
+}
+`, rName, instanceClass, promotionTier))
+}
+
+func testAccClusterInstanceConfig_deprecatedEngineError(rName string) string {
+	return acctest.ConfigCompose(testAccClusterInstanceConfig_baseCluster(rName), fmt.Sprintf(`
+resource "aws_rds_cluster_instance" "test" {
+  cluster_identifier      = aws_rds_cluster.test.id
+  identifier              = %[1]q
+  instance_class          = "db.t3.small"
+  engine                  = "aurora"
+  deprecated_engine_error = true
+}
+`, rName))
+}
+
+func testAccClusterInstanceConfig_instanceClassIgnoreChanges(rName string) string {
+	return acctest.ConfigCompose(testAccClusterInstanceConfig_baseCluster(rName), fmt.Sprintf(`
+resource "aws_rds_cluster_instance" "test" {
+  cluster_identifier = aws_rds_cluster.test.id
+  identifier         = %[1]q
+  instance_class     = "db.t3.small"
+
+  lifecycle {
+    ignore_changes = [instance_class]
+  }
+}
+`, rName))
+}
+
+func testAccClusterInstanceConfig_autoMinorVersionUpgradeWithEngineVersion(rName, engineVersion string, autoMinorVersionUpgrade bool) string {
+	return fmt.Sprintf(`
+resource "aws_rds_cluster" "test" {
+  apply_immediately   = true
+  cluster_identifier  = %[1]q
+  engine              = "aurora-mysql"
+  engine_version      = %[2]q
+  master_username     = "foo"
+  master_password     = "mustbeeightcharacters"
+  skip_final_snapshot = true
+}
+
+data "aws_rds_orderable_db_instance" "test" {
+  engine                     = aws_rds_cluster.test.engine
+  engine_version             = aws_rds_cluster.test.engine_version
+  preferred_instance_classes = ["db.t3.medium", "db.r5.large", "db.r4.large"]
+}
+
+resource "aws_rds_cluster_instance" "test" {
+  apply_immediately          = true
+  auto_minor_version_upgrade = %[3]t
+  cluster_identifier         = aws_rds_cluster.test.id
+  engine                     = aws_rds_cluster.test.engine
+  engine_version             = aws_rds_cluster.test.engine_version
+  identifier                 = %[1]q
+  instance_class             = data.aws_rds_orderable_db_instance.test.instance_class
+}
+`, rName, engineVersion, autoMinorVersionUpgrade)
+}
+
+// testAccClusterInstanceConfig_autoMinorVersionUpgradeWithInstanceEngineVersion changes only the instance's engine_version,
+// the cluster keeps running 5.7.mysql_aurora.2.10.1.
+func testAccClusterInstanceConfig_autoMinorVersionUpgradeWithInstanceEngineVersion(rName, engineVersion string, autoMinorVersionUpgrade bool) string {
+	return fmt.Sprintf(`
+resource "aws_rds_cluster" "test" {
+  apply_immediately   = true
+  cluster_identifier  = %[1]q
+  engine              = "aurora-mysql"
+  engine_version      = "5.7.mysql_aurora.2.10.1"
+  master_username     = "foo"
+  master_password     = "mustbeeightcharacters"
+  skip_final_snapshot = true
+}
+
+data "aws_rds_orderable_db_instance" "test" {
+  engine                     = aws_rds_cluster.test.engine
+  engine_version             = aws_rds_cluster.test.engine_version
+  preferred_instance_classes = ["db.t3.medium", "db.r5.large", "db.r4.large"]
+}
+
+resource "aws_rds_cluster_instance" "test" {
+  apply_immediately          = true
+  auto_minor_version_upgrade = %[3]t
+  cluster_identifier         = aws_rds_cluster.test.id
+  engine                     = aws_rds_cluster.test.engine
+  engine_version             = %[2]q
+  identifier                 = %[1]q
+  instance_class             = data.aws_rds_orderable_db_instance.test.instance_class
+}
+`, rName, engineVersion, autoMinorVersionUpgrade)
+}
+
+func testAccClusterInstanceConfig_tags1(rName, tagKey1, tagValue1 string) string {
+	return acctest.ConfigCompose(testAccClusterInstanceConfig_baseCluster(rName), fmt.Sprintf(`
+data "aws_rds_orderable_db_instance" "test" {
+  engine                     = aws_rds_cluster.test.engine
+  engine_version             = aws_rds_cluster.test.engine_version
+  preferred_instance_classes = ["db.t3.small", "db.t2.small", "db.t3.medium"]
+}
+
+resource "aws_rds_cluster_instance" "test" {
+  cluster_identifier = aws_rds_cluster.test.id
+  identifier         = %[1]q
+  instance_class     = data.aws_rds_orderable_db_instance.test.instance_class
+
+  tags = {
+    %[2]q = %[3]q
+  }
+}
+`, rName, tagKey1, tagValue1))
+}
+
+func testAccClusterInstanceConfig_rebootTrigger(rName, rebootTrigger string) string {
+	return acctest.ConfigCompose(testAccClusterInstanceConfig_baseCluster(rName), fmt.Sprintf(`
+data "aws_rds_orderable_db_instance" "test" {
+  engine                     = aws_rds_cluster.test.engine
+  engine_version             = aws_rds_cluster.test.engine_version
+  preferred_instance_classes = ["db.t3.small", "db.t2.small", "db.t3.medium"]
+}
+
+resource "aws_rds_cluster_instance" "test" {
+  cluster_identifier = aws_rds_cluster.test.id
+  identifier         = %[1]q
+  instance_class     = data.aws_rds_orderable_db_instance.test.instance_class
+  reboot_trigger     = %[2]q
+}
+`, rName, rebootTrigger))
+}
+
+func testAccClusterInstanceConfig_parallelReaders(rName string, count int) string {
+	return fmt.Sprintf(`
+resource "aws_rds_cluster" "test" {
+  cluster_identifier  = %[1]q
+  master_username     = "foo"
+  master_password     = "mustbeeightcharacters"
+  skip_final_snapshot = true
+}
+
+data "aws_rds_orderable_db_instance" "test" {
+  engine                     = aws_rds_cluster.test.engine
+  engine_version             = aws_rds_cluster.test.engine_version
+  preferred_instance_classes = ["db.t3.small", "db.t2.small", "db.t3.medium"]
+}
+
+resource "aws_rds_cluster_instance" "test" {
+  count = %[2]d
+
+  cluster_identifier = aws_rds_cluster.test.id
+  identifier         = "%[1]s-${count.index}"
+  instance_class     = data.aws_rds_orderable_db_instance.test.instance_class
+}
+`, rName, count)
+}
+
+func testAccClusterInstanceConfig_dbSubnetGroupName(rName string, subnetGroupIndex int) string {
+	return acctest.ConfigCompose(acctest.ConfigVPCWithSubnets(rName, 2), fmt.Sprintf(`
+resource "aws_db_subnet_group" "test" {
+  count = 2
+
+  name       = "%[1]s-${count.index}"
+  subnet_ids = aws_subnet.test[*].id
+}
+
+resource "aws_rds_cluster" "test" {
+  cluster_identifier   = %[1]q
+  db_subnet_group_name = aws_db_subnet_group.test[%[2]d].name
+  master_username      = "foo"
+  master_password      = "mustbeeightcharacters"
+  skip_final_snapshot  = true
+}
+
+data "aws_rds_orderable_db_instance" "test" {
+  engine                     = aws_rds_cluster.test.engine
+  engine_version             = aws_rds_cluster.test.engine_version
+  preferred_instance_classes = ["db.t3.small", "db.t2.small", "db.t3.medium"]
+}
+
+resource "aws_rds_cluster_instance" "test" {
+  cluster_identifier   = aws_rds_cluster.test.id
+  db_subnet_group_name = aws_db_subnet_group.test[%[2]d].name
+  identifier           = %[1]q
+  instance_class       = data.aws_rds_orderable_db_instance.test.instance_class
+}
+`, rName, subnetGroupIndex))
+}
@@ -22,21 +22,44 @@ func StorageType_Values() []string {
 	}
 }
 
+const (
+	instanceClassServerless = "db.serverless"
+)
+
+const (
+	snapshotTypeAutomated = "automated"
+)
+
+const (
+	// engineVersionLatest is resolved to the newest available engine version when a cluster instance is created.
+	engineVersionLatest = "latest"
+)
+
+// https://docs.aws.amazon.com/AmazonRDS/latest/AuroraUserGuide/accessing-monitoring.html#Aurora.Status.
+const (
+	ClusterStatusAvailable = "available"
+	ClusterStatusDeleting  = "deleting"
+	ClusterStatusUpgrading = "upgrading"
+)
+
 // https://docs.aws.amazon.com/AmazonRDS/latest/UserGuide/accessing-monitoring.html#Overview.DBInstance.Status.
 const (
-	InstanceStatusAvailable                     = "available"
-	InstanceStatusBackingUp                     = "backing-up"
-	InstanceStatusConfiguringEnhancedMonitoring = "configuring-enhanced-monitoring"
-	InstanceStatusConfiguringLogExports         = "configuring-log-exports"
-	InstanceStatusCreating                      = "creating"
-	InstanceStatusDeleting                      = "deleting"
-	InstanceStatusIncompatibleParameters        = "incompatible-parameters"
-	InstanceStatusIncompatibleRestore           = "incompatible-restore"
-	InstanceStatusModifying                     = "modifying"
-	InstanceStatusStarting                      = "starting"
-	InstanceStatusStopping                      = "stopping"
-	InstanceStatusStorageFull                   = "storage-full"
-	InstanceStatusStorageOptimization           = "storage-optimization"
+	InstanceStatusAvailable                         = "available"
+	InstanceStatusBackingUp                         = "backing-up"
+	InstanceStatusConfiguringEnhancedMonitoring     = "configuring-enhanced-monitoring"
+	InstanceStatusConfiguringLogExports             = "configuring-log-exports"
+	InstanceStatusCreating                          = "creating"
+	InstanceStatusDeleting                          = "deleting"
+	InstanceStatusFailed                            = "failed"
+	InstanceStatusInaccessibleEncryptionCredentials = "inaccessible-encryption-credentials"
+	InstanceStatusIncompatibleNetwork               = "incompatible-network"
+	InstanceStatusIncompatibleParameters            = "incompatible-parameters"
+	InstanceStatusIncompatibleRestore               = "incompatible-restore"
+	InstanceStatusModifying                         = "modifying"
+	InstanceStatusStarting                          = "starting"
+	InstanceStatusStopping                          = "stopping"
+	InstanceStatusStorageFull                       = "storage-full"
+	InstanceStatusStorageOptimization               = "storage-optimization"
 )
 
 const (
@@ -60,6 +83,11 @@ const (
 	EnginePostgres         = "postgres"
 )
 
+const (
+	enginePortMySQLDefault      = 3306
+	enginePortPostgreSQLDefault = 5432
+)
+
 func Engine_Values() []string {
 	return []string{
 		EngineAurora,
@@ -128,6 +156,18 @@ func InstanceExportableLogType_Values() []string {
 	}
 }
 
+const (
+	BackupTargetOutposts = "outposts"
+	BackupTargetRegion   = "region"
+)
+
+func BackupTarget_Values() []string {
+	return []string{
+		BackupTargetOutposts,
+		BackupTargetRegion,
+	}
+}
+
 const (
 	RestoreTypeCopyOnWrite = "copy-on-write"
 	RestoreTypeFullCopy    = "full-copy"
@@ -152,6 +192,98 @@ func TimeoutAction_Values() []string {
 	}
 }
 
+const (
+	ProxyTargetDeleteCheckError = "error"
+	ProxyTargetDeleteCheckWarn  = "warn"
+)
+
+func ProxyTargetDeleteCheck_Values() []string {
+	return []string{
+		ProxyTargetDeleteCheckError,
+		ProxyTargetDeleteCheckWarn,
+	}
+}
+
 const (
 	propagationTimeout = 2 * time.Minute
+
+	// clusterInstanceIAMPropagationTimeout is how long cluster instance creates and updates are retried
+	// while the IAM role (e.g. monitoring_role_arn) is not yet usable by RDS.
+	// Increase it for environments where IAM propagation is slow, such as cross-account roles.
+	clusterInstanceIAMPropagationTimeout = propagationTimeout
+
+	// clusterInstanceTagsThrottleTimeout is how long reading the tags of a cluster instance is retried while
+	// RDS throttles the requests, in addition to the AWS SDK's own retries.
+	clusterInstanceTagsThrottleTimeout = 2 * time.Minute
+
+	// clusterInstancePromotionTierAppliedTimeout is how long an update waits for a changed promotion tier to be reported.
+	clusterInstancePromotionTierAppliedTimeout = 5 * time.Minute
+)
+
+const (
+	errCodeAccessDenied                = "AccessDenied"
+	errCodeInvalidParameterCombination = "InvalidParameterCombination"
+	errCodeThrottling                  = "Throttling"
+)
+
+const (
+	// clusterInstanceFastPollEnvVar is the environment variable that, when set, e.g. in acceptance tests,
+	// shortens the delays of waiting for cluster instances to be created, updated or deleted.
+	clusterInstanceFastPollEnvVar = "TF_RDS_FAST_POLL"
+
+	clusterInstancePollDelay          = 30 * time.Second
+	clusterInstancePollMinTimeout     = 10 * time.Second
+	clusterInstanceFastPollDelay      = 5 * time.Second
+	clusterInstanceFastPollMinTimeout = 5 * time.Second
+)
+
+const (
+	// clusterInstanceCreateTimeout is the default create timeout of a cluster instance.
+	clusterInstanceCreateTimeout = 90 * time.Minute
+)
+
+const (
+	parameterApplyStatusPendingReboot = "pending-reboot"
+)
+
+const (
+	caCertKeyTypeECDSA = "ECDSA"
+	caCertKeyTypeRSA   = "RSA"
+)
+
+const (
+	OptInTypeImmediate       = "immediate"
+	OptInTypeNextMaintenance = "next-maintenance"
+	OptInTypeUndoOptIn       = "undo-opt-in"
+)
+
+func OptInType_Values() []string {
+	return []string{
+		OptInTypeImmediate,
+		OptInTypeNextMaintenance,
+		OptInTypeUndoOptIn,
+	}
+}
+
+const (
+	licenseModelGeneralPublicLicense = "general-public-license"
+	licenseModelPostgreSQLLicense    = "postgresql-license"
+)
+
+const (
+	// performanceInsightsRetentionPeriodDefault is the retention period, in days, of Performance Insights data
+	// when none is specified, the free tier.
+	performanceInsightsRetentionPeriodDefault = 7
+)
+
+const (
+	// caCertificateExpiringSoonThreshold is how long before its expiry a CA certificate is considered to be expiring soon.
+	caCertificateExpiringSoonThreshold = 90 * 24 * time.Hour
+)
+
+const (
+	// Tag limits of RDS resources. Tags with the "aws:" prefix don't count towards the limits.
+	maxTagsPerResource = 50
+	maxTagKeyLength    = 128
+	maxTagValueLength  = 256
 )
@@ -2,6 +2,9 @@ package rds
 
 import (
 	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/rds"
 )
 
 func TestCompareActualEngineVersion(t *testing.T) {
@@ -74,3 +77,343 @@ func TestCompareActualEngineVersion(t *testing.T) {
 		})
 	}
 }
+
+func TestEngineVersionMajor(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		engine   string
+		version  string
+		expected string
+	}
+	tests := map[string]testCase{
+		"aurora": {
+			engine:   EngineAurora,
+			version:  "5.6.mysql_aurora.1.22.2",
+			expected: "5.6",
+		},
+		"aurora 5.6.10a": {
+			engine:   EngineAurora,
+			version:  "5.6.10a",
+			expected: "5.6",
+		},
+		"aurora-mysql 2": {
+			engine:   EngineAuroraMySQL,
+			version:  "5.7.mysql_aurora.2.10.2",
+			expected: "5.7",
+		},
+		"aurora-mysql 3": {
+			engine:   EngineAuroraMySQL,
+			version:  "8.0.mysql_aurora.3.02.0",
+			expected: "8.0",
+		},
+		"aurora-postgresql 15": {
+			engine:   EngineAuroraPostgreSQL,
+			version:  "15.4",
+			expected: "15",
+		},
+		"aurora-postgresql 9.6": {
+			engine:   EngineAuroraPostgreSQL,
+			version:  "9.6.22",
+			expected: "9.6",
+		},
+		"mysql": {
+			engine:   EngineMySQL,
+			version:  "8.0.28",
+			expected: "8.0",
+		},
+		"postgres": {
+			engine:   EnginePostgres,
+			version:  "13.7",
+			expected: "13",
+		},
+		"postgres major only": {
+			engine:   EnginePostgres,
+			version:  "14",
+			expected: "14",
+		},
+		"empty": {
+			engine:   EngineAuroraMySQL,
+			version:  "",
+			expected: "",
+		},
+	}
+
+	for name, test := range tests {
+		name, test := name, test
+		t.Run(name, func(t *testing.T) {
+			if got := engineVersionMajor(test.engine, test.version); got != test.expected {
+				t.Errorf("unexpected engine version major; want: %q, got: %q", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestClusterSetResourceDataEngineVersionFromClusterInstance(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		configuredVersion           string
+		actualVersion               string
+		expectedEngineVersion       string
+		expectedEngineVersionActual string
+	}
+	tests := map[string]testCase{
+		"aurora-mysql pinned minor with patch upgrade": {
+			configuredVersion:           "8.0.mysql_aurora.3.02",
+			actualVersion:               "8.0.mysql_aurora.3.02.0",
+			expectedEngineVersion:       "8.0.mysql_aurora.3.02",
+			expectedEngineVersionActual: "8.0.mysql_aurora.3.02.0",
+		},
+		"aurora-postgresql pinned major with minor upgrade": {
+			configuredVersion:           "13",
+			actualVersion:               "13.7",
+			expectedEngineVersion:       "13",
+			expectedEngineVersionActual: "13.7",
+		},
+		"not configured": {
+			configuredVersion:           "",
+			actualVersion:               "5.6.mysql_aurora.1.22.2",
+			expectedEngineVersion:       "5.6.mysql_aurora.1.22.2",
+			expectedEngineVersionActual: "5.6.mysql_aurora.1.22.2",
+		},
+		"minor version upgrade": {
+			configuredVersion:           "5.7.mysql_aurora.2.07",
+			actualVersion:               "5.7.mysql_aurora.2.10.2",
+			expectedEngineVersion:       "5.7.mysql_aurora.2.10.2",
+			expectedEngineVersionActual: "5.7.mysql_aurora.2.10.2",
+		},
+		"latest": {
+			configuredVersion:           "latest",
+			actualVersion:               "13.10",
+			expectedEngineVersion:       "latest",
+			expectedEngineVersionActual: "13.10",
+		},
+	}
+
+	for name, test := range tests {
+		name, test := name, test
+		t.Run(name, func(t *testing.T) {
+			r := ResourceClusterInstance()
+			d := r.Data(nil)
+			d.Set("engine_version", test.configuredVersion)
+			clusterSetResourceDataEngineVersionFromClusterInstance(d, &rds.DBInstance{
+				EngineVersion: aws.String(test.actualVersion),
+			})
+
+			if want, got := test.expectedEngineVersion, d.Get("engine_version"); got != want {
+				t.Errorf("unexpected engine_version; want: %q, got: %q", want, got)
+			}
+			if want, got := test.expectedEngineVersionActual, d.Get("engine_version_actual"); got != want {
+				t.Errorf("unexpected engine_version_actual; want: %q, got: %q", want, got)
+			}
+		})
+	}
+}
+
+func TestEngineVersionSatisfies(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		configuredVersion string
+		actualVersion     string
+		expected          bool
+	}
+	tests := map[string]testCase{
+		"same version": {
+			configuredVersion: "5.7.mysql_aurora.2.10.2",
+			actualVersion:     "5.7.mysql_aurora.2.10.2",
+			expected:          true,
+		},
+		"point version": {
+			configuredVersion: "8.0",
+			actualVersion:     "8.0.27",
+			expected:          true,
+		},
+		"older version": {
+			configuredVersion: "5.7.mysql_aurora.2.10.2",
+			actualVersion:     "5.7.mysql_aurora.2.10.1",
+			expected:          false,
+		},
+		"shared prefix": {
+			configuredVersion: "13.1",
+			actualVersion:     "13.10",
+			expected:          false,
+		},
+	}
+
+	for name, test := range tests {
+		name, test := name, test
+		t.Run(name, func(t *testing.T) {
+			if got := engineVersionSatisfies(test.configuredVersion, test.actualVersion); got != test.expected {
+				t.Errorf("unexpected result; want: %t, got: %t", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestDBClusterEngineVersionApplying(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		dbCluster *rds.DBCluster
+		expected  bool
+	}
+	tests := map[string]testCase{
+		"running version": {
+			dbCluster: &rds.DBCluster{
+				EngineVersion: aws.String("5.7.mysql_aurora.2.10.2"),
+				Status:        aws.String(ClusterStatusAvailable),
+			},
+			expected: true,
+		},
+		"other version": {
+			dbCluster: &rds.DBCluster{
+				EngineVersion: aws.String("5.7.mysql_aurora.2.10.1"),
+				Status:        aws.String(ClusterStatusAvailable),
+			},
+			expected: false,
+		},
+		"upgrade deferred": {
+			dbCluster: &rds.DBCluster{
+				EngineVersion: aws.String("5.7.mysql_aurora.2.10.1"),
+				PendingModifiedValues: &rds.ClusterPendingModifiedValues{
+					EngineVersion: aws.String("5.7.mysql_aurora.2.10.2"),
+				},
+				Status: aws.String(ClusterStatusAvailable),
+			},
+			expected: false,
+		},
+		"upgrading": {
+			dbCluster: &rds.DBCluster{
+				EngineVersion: aws.String("5.7.mysql_aurora.2.10.1"),
+				PendingModifiedValues: &rds.ClusterPendingModifiedValues{
+					EngineVersion: aws.String("5.7.mysql_aurora.2.10.2"),
+				},
+				Status: aws.String(ClusterStatusUpgrading),
+			},
+			expected: true,
+		},
+		"upgrading to other version": {
+			dbCluster: &rds.DBCluster{
+				EngineVersion: aws.String("5.7.mysql_aurora.2.10.1"),
+				PendingModifiedValues: &rds.ClusterPendingModifiedValues{
+					EngineVersion: aws.String("5.7.mysql_aurora.2.10.3"),
+				},
+				Status: aws.String(ClusterStatusUpgrading),
+			},
+			expected: false,
+		},
+		"upgrading without reported target": {
+			dbCluster: &rds.DBCluster{
+				EngineVersion: aws.String("5.7.mysql_aurora.2.10.1"),
+				Status:        aws.String(ClusterStatusUpgrading),
+			},
+			expected: true,
+		},
+	}
+
+	for name, test := range tests {
+		name, test := name, test
+		t.Run(name, func(t *testing.T) {
+			if got := dbClusterEngineVersionApplying(test.dbCluster, "5.7.mysql_aurora.2.10.2"); got != test.expected {
+				t.Errorf("unexpected result; want: %t, got: %t", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestCompareEngineVersions(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		a        string
+		b        string
+		expected int
+	}
+	tests := map[string]testCase{
+		"equal": {
+			a:        "13.7",
+			b:        "13.7",
+			expected: 0,
+		},
+		"numeric minor": {
+			a:        "13.10",
+			b:        "13.9",
+			expected: 1,
+		},
+		"numeric major": {
+			a:        "9.6.22",
+			b:        "10.21",
+			expected: -1,
+		},
+		"aurora mysql": {
+			a:        "5.7.mysql_aurora.2.10.2",
+			b:        "5.7.mysql_aurora.2.11.0",
+			expected: -1,
+		},
+		"longer": {
+			a:        "8.0.28",
+			b:        "8.0",
+			expected: 1,
+		},
+	}
+
+	for name, test := range tests {
+		name, test := name, test
+		t.Run(name, func(t *testing.T) {
+			got := compareEngineVersions(test.a, test.b)
+
+			if (got < 0 && test.expected >= 0) || (got > 0 && test.expected <= 0) || (got == 0 && test.expected != 0) {
+				t.Errorf("unexpected result; want sign of: %d, got: %d", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestLatestEngineVersion(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		engineVersions []*rds.DBEngineVersion
+		expected       string
+	}
+	tests := map[string]testCase{
+		"none": {
+			engineVersions: nil,
+			expected:       "",
+		},
+		"aurora-postgresql": {
+			engineVersions: []*rds.DBEngineVersion{
+				{EngineVersion: aws.String("13.9"), Status: aws.String("available")},
+				{EngineVersion: aws.String("13.10"), Status: aws.String("available")},
+				{EngineVersion: aws.String("9.6.22"), Status: aws.String("available")},
+			},
+			expected: "13.10",
+		},
+		"aurora-mysql unordered": {
+			engineVersions: []*rds.DBEngineVersion{
+				{EngineVersion: aws.String("5.7.mysql_aurora.2.11.0")},
+				{EngineVersion: aws.String("8.0.mysql_aurora.3.02.0")},
+				{EngineVersion: aws.String("5.7.mysql_aurora.2.10.2")},
+			},
+			expected: "8.0.mysql_aurora.3.02.0",
+		},
+		"deprecated newest": {
+			engineVersions: []*rds.DBEngineVersion{
+				{EngineVersion: aws.String("13.9"), Status: aws.String("available")},
+				{EngineVersion: aws.String("13.10"), Status: aws.String("deprecated")},
+			},
+			expected: "13.9",
+		},
+	}
+
+	for name, test := range tests {
+		name, test := name, test
+		t.Run(name, func(t *testing.T) {
+			if got := latestEngineVersion(test.engineVersions); got != test.expected {
+				t.Errorf("unexpected latest engine version; want: %q, got: %q", test.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package rds
+
+import "fmt"
+
+const (
+	ClusterInstanceOperationCreate = "create"
+	ClusterInstanceOperationRead   = "read"
+	ClusterInstanceOperationUpdate = "update"
+	ClusterInstanceOperationDelete = "delete"
+)
+
+// ClusterInstanceError is an error returned by an operation on an RDS Cluster Instance.
+// Its message is that of the underlying error, which it wraps.
+type ClusterInstanceError struct {
+	Operation  string
+	InstanceID string
+	Err        error
+}
+
+func NewClusterInstanceError(operation, instanceID string, err error) error {
+	return &ClusterInstanceError{
+		Operation:  operation,
+		InstanceID: instanceID,
+		Err:        err,
+	}
+}
+
+func (e *ClusterInstanceError) Error() string {
+	if e.Err == nil {
+		return fmt.Sprintf("RDS Cluster Instance (%s) %s failed", e.InstanceID, e.Operation)
+	}
+
+	return e.Err.Error()
+}
+
+func (e *ClusterInstanceError) Unwrap() error {
+	return e.Err
+}
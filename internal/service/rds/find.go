@@ -1,10 +1,12 @@
 package rds
 
 import (
+	"fmt"
 	"log"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/rds"
+	"github.com/aws/aws-sdk-go/service/rds/rdsiface"
 	"github.com/hashicorp/aws-sdk-go-base/v2/awsv1shim/v2/tfawserr"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-provider-aws/internal/tfresource"
@@ -90,7 +92,7 @@ func FindDBClusterRoleByDBClusterIDAndRoleARN(conn *rds.RDS, dbClusterID, roleAR
 	return nil, &resource.NotFoundError{}
 }
 
-func FindDBClusterByID(conn *rds.RDS, id string) (*rds.DBCluster, error) {
+func FindDBClusterByID(conn rdsiface.RDSAPI, id string) (*rds.DBCluster, error) {
 	input := &rds.DescribeDBClustersInput{
 		DBClusterIdentifier: aws.String(id),
 	}
@@ -157,12 +159,12 @@ func FindDBClusterWithActivityStream(conn *rds.RDS, dbClusterArn string) (*rds.D
 	return dbCluster, nil
 }
 
-func FindDBInstanceByID(conn *rds.RDS, id string) (*rds.DBInstance, error) {
+func FindDBInstanceByID(conn rdsiface.RDSAPI, id string) (*rds.DBInstance, error) {
 	input := &rds.DescribeDBInstancesInput{
 		DBInstanceIdentifier: aws.String(id),
 	}
 
-	output, err := conn.DescribeDBInstances(input)
+	output, err := FindDBInstances(conn, input)
 
 	if tfawserr.ErrCodeEquals(err, rds.ErrCodeDBInstanceNotFoundFault) {
 		return nil, &resource.NotFoundError{
@@ -171,11 +173,15 @@ func FindDBInstanceByID(conn *rds.RDS, id string) (*rds.DBInstance, error) {
 		}
 	}
 
-	if output == nil || len(output.DBInstances) == 0 || output.DBInstances[0] == nil {
+	if err != nil {
+		return nil, err
+	}
+
+	if len(output) == 0 {
 		return nil, tfresource.NewEmptyResultError(input)
 	}
 
-	dbInstance := output.DBInstances[0]
+	dbInstance := output[0]
 
 	// Eventual consistency check.
 	if aws.StringValue(dbInstance.DBInstanceIdentifier) != id {
@@ -187,6 +193,322 @@ func FindDBInstanceByID(conn *rds.RDS, id string) (*rds.DBInstance, error) {
 	return dbInstance, nil
 }
 
+// FindDBInstances returns all DB instances matching the specified input, e.g. its Filters, from all result pages.
+func FindDBInstances(conn rdsiface.RDSAPI, input *rds.DescribeDBInstancesInput) ([]*rds.DBInstance, error) {
+	var output []*rds.DBInstance
+
+	err := conn.DescribeDBInstancesPages(input, func(page *rds.DescribeDBInstancesOutput, lastPage bool) bool {
+		if page == nil {
+			return !lastPage
+		}
+
+		for _, v := range page.DBInstances {
+			if v != nil {
+				output = append(output, v)
+			}
+		}
+
+		return !lastPage
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
+
+// FindPendingMaintenanceActionsByResourceARN returns the maintenance actions that are pending for the resource
+// with the specified ARN, from all result pages.
+func FindPendingMaintenanceActionsByResourceARN(conn rdsiface.RDSAPI, arn string) ([]*rds.PendingMaintenanceAction, error) {
+	input := &rds.DescribePendingMaintenanceActionsInput{
+		ResourceIdentifier: aws.String(arn),
+	}
+	var output []*rds.PendingMaintenanceAction
+
+	err := conn.DescribePendingMaintenanceActionsPages(input, func(page *rds.DescribePendingMaintenanceActionsOutput, lastPage bool) bool {
+		if page == nil {
+			return !lastPage
+		}
+
+		for _, v := range page.PendingMaintenanceActions {
+			if v == nil || aws.StringValue(v.ResourceIdentifier) != arn {
+				continue
+			}
+
+			for _, action := range v.PendingMaintenanceActionDetails {
+				if action != nil {
+					output = append(output, action)
+				}
+			}
+		}
+
+		return !lastPage
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
+
+// FindDBClusterInstanceByTwoPartID returns the DB instance with the specified identifier
+// if it is a member of the DB cluster with the specified identifier.
+func FindDBClusterInstanceByTwoPartID(conn rdsiface.RDSAPI, clusterID, instanceID string) (*rds.DBInstance, error) {
+	dbInstance, err := FindDBInstanceByID(conn, instanceID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if v := aws.StringValue(dbInstance.DBClusterIdentifier); v != clusterID {
+		message := fmt.Sprintf("RDS DB Instance (%s) is not a member of RDS Cluster (%s)", instanceID, clusterID)
+
+		if v != "" {
+			message = fmt.Sprintf("%s, it is a member of RDS Cluster (%s)", message, v)
+		}
+
+		return nil, &resource.NotFoundError{
+			Message: message,
+		}
+	}
+
+	return dbInstance, nil
+}
+
+func FindDBSnapshotByID(conn *rds.RDS, id string) (*rds.DBSnapshot, error) {
+	input := &rds.DescribeDBSnapshotsInput{
+		DBSnapshotIdentifier: aws.String(id),
+	}
+
+	output, err := conn.DescribeDBSnapshots(input)
+
+	if tfawserr.ErrCodeEquals(err, rds.ErrCodeDBSnapshotNotFoundFault) {
+		return nil, &resource.NotFoundError{
+			LastError:   err,
+			LastRequest: input,
+		}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if output == nil || len(output.DBSnapshots) == 0 || output.DBSnapshots[0] == nil {
+		return nil, tfresource.NewEmptyResultError(input)
+	}
+
+	dbSnapshot := output.DBSnapshots[0]
+
+	// Eventual consistency check.
+	if aws.StringValue(dbSnapshot.DBSnapshotIdentifier) != id {
+		return nil, &resource.NotFoundError{
+			LastRequest: input,
+		}
+	}
+
+	return dbSnapshot, nil
+}
+
+func FindDBParameterGroupByName(conn rdsiface.RDSAPI, name string) (*rds.DBParameterGroup, error) {
+	input := &rds.DescribeDBParameterGroupsInput{
+		DBParameterGroupName: aws.String(name),
+	}
+
+	output, err := conn.DescribeDBParameterGroups(input)
+
+	if tfawserr.ErrCodeEquals(err, rds.ErrCodeDBParameterGroupNotFoundFault) {
+		return nil, &resource.NotFoundError{
+			LastError:   err,
+			LastRequest: input,
+		}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if output == nil || len(output.DBParameterGroups) == 0 || output.DBParameterGroups[0] == nil {
+		return nil, tfresource.NewEmptyResultError(input)
+	}
+
+	dbParameterGroup := output.DBParameterGroups[0]
+
+	// Eventual consistency check.
+	if aws.StringValue(dbParameterGroup.DBParameterGroupName) != name {
+		return nil, &resource.NotFoundError{
+			LastRequest: input,
+		}
+	}
+
+	return dbParameterGroup, nil
+}
+
+func FindDBSubnetGroupByName(conn rdsiface.RDSAPI, name string) (*rds.DBSubnetGroup, error) {
+	input := &rds.DescribeDBSubnetGroupsInput{
+		DBSubnetGroupName: aws.String(name),
+	}
+
+	output, err := conn.DescribeDBSubnetGroups(input)
+
+	if tfawserr.ErrCodeEquals(err, rds.ErrCodeDBSubnetGroupNotFoundFault) {
+		return nil, &resource.NotFoundError{
+			LastError:   err,
+			LastRequest: input,
+		}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if output == nil || len(output.DBSubnetGroups) == 0 || output.DBSubnetGroups[0] == nil {
+		return nil, tfresource.NewEmptyResultError(input)
+	}
+
+	dbSubnetGroup := output.DBSubnetGroups[0]
+
+	// Eventual consistency check.
+	if aws.StringValue(dbSubnetGroup.DBSubnetGroupName) != name {
+		return nil, &resource.NotFoundError{
+			LastRequest: input,
+		}
+	}
+
+	return dbSubnetGroup, nil
+}
+
+func FindCertificateByID(conn *rds.RDS, id string) (*rds.Certificate, error) {
+	input := &rds.DescribeCertificatesInput{
+		CertificateIdentifier: aws.String(id),
+	}
+
+	output, err := findCertificates(conn, input)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(output) == 0 || output[0] == nil {
+		return nil, tfresource.NewEmptyResultError(input)
+	}
+
+	if count := len(output); count > 1 {
+		return nil, tfresource.NewTooManyResultsError(count, input)
+	}
+
+	certificate := output[0]
+
+	// Eventual consistency check.
+	if aws.StringValue(certificate.CertificateIdentifier) != id {
+		return nil, &resource.NotFoundError{
+			LastRequest: input,
+		}
+	}
+
+	return certificate, nil
+}
+
+func findCertificates(conn *rds.RDS, input *rds.DescribeCertificatesInput) ([]*rds.Certificate, error) {
+	var output []*rds.Certificate
+
+	err := conn.DescribeCertificatesPages(input, func(page *rds.DescribeCertificatesOutput, lastPage bool) bool {
+		if page == nil {
+			return !lastPage
+		}
+
+		for _, v := range page.Certificates {
+			if v != nil {
+				output = append(output, v)
+			}
+		}
+
+		return !lastPage
+	})
+
+	if tfawserr.ErrCodeEquals(err, rds.ErrCodeCertificateNotFoundFault) {
+		return nil, &resource.NotFoundError{
+			LastError:   err,
+			LastRequest: input,
+		}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
+
+func findLatestDBEngineVersion(conn *rds.RDS, engine string) (string, error) {
+	input := &rds.DescribeDBEngineVersionsInput{
+		Engine: aws.String(engine),
+	}
+
+	output, err := findDBEngineVersions(conn, input)
+
+	if err != nil {
+		return "", err
+	}
+
+	engineVersion := latestEngineVersion(output)
+
+	if engineVersion == "" {
+		return "", tfresource.NewEmptyResultError(input)
+	}
+
+	return engineVersion, nil
+}
+
+func findDBEngineVersions(conn rdsiface.RDSAPI, input *rds.DescribeDBEngineVersionsInput) ([]*rds.DBEngineVersion, error) {
+	var output []*rds.DBEngineVersion
+
+	err := conn.DescribeDBEngineVersionsPages(input, func(page *rds.DescribeDBEngineVersionsOutput, lastPage bool) bool {
+		if page == nil {
+			return !lastPage
+		}
+
+		for _, v := range page.DBEngineVersions {
+			if v != nil {
+				output = append(output, v)
+			}
+		}
+
+		return !lastPage
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
+
+func findOrderableDBInstanceOptions(conn rdsiface.RDSAPI, input *rds.DescribeOrderableDBInstanceOptionsInput) ([]*rds.OrderableDBInstanceOption, error) {
+	var output []*rds.OrderableDBInstanceOption
+
+	err := conn.DescribeOrderableDBInstanceOptionsPages(input, func(page *rds.DescribeOrderableDBInstanceOptionsOutput, lastPage bool) bool {
+		if page == nil {
+			return !lastPage
+		}
+
+		for _, v := range page.OrderableDBInstanceOptions {
+			if v != nil {
+				output = append(output, v)
+			}
+		}
+
+		return !lastPage
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
+
 func FindDBProxyByName(conn *rds.RDS, name string) (*rds.DBProxy, error) {
 	input := &rds.DescribeDBProxiesInput{
 		DBProxyName: aws.String(name),
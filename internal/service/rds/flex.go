@@ -1,9 +1,12 @@
 package rds
 
 import (
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/rds"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-provider-aws/internal/flex"
@@ -291,3 +294,422 @@ func FlattenParameters(list []*rds.Parameter) []map[string]interface{} {
 
 	return result
 }
+
+// flattenClusterInstanceAvailabilityZone returns the Availability Zone of a cluster instance.
+// While an instance is being created the API may not yet report its Availability Zone. In that case
+// the previously known value is kept or, if the instance's subnet group or its cluster only spans a
+// single Availability Zone, that Availability Zone is used.
+func flattenClusterInstanceAvailabilityZone(current string, dbInstance *rds.DBInstance, dbCluster *rds.DBCluster) string {
+	if v := aws.StringValue(dbInstance.AvailabilityZone); v != "" {
+		return v
+	}
+
+	if current != "" {
+		return current
+	}
+
+	if v := dbInstance.DBSubnetGroup; v != nil {
+		azs := make(map[string]struct{})
+
+		for _, subnet := range v.Subnets {
+			if subnet == nil || subnet.SubnetAvailabilityZone == nil {
+				continue
+			}
+
+			azs[aws.StringValue(subnet.SubnetAvailabilityZone.Name)] = struct{}{}
+		}
+
+		if len(azs) == 1 {
+			for az := range azs {
+				return az
+			}
+		}
+	}
+
+	if dbCluster != nil && len(dbCluster.AvailabilityZones) == 1 {
+		return aws.StringValue(dbCluster.AvailabilityZones[0])
+	}
+
+	return ""
+}
+
+// flattenClusterInstanceAvailabilityZoneID returns the ID of the specified Availability Zone of a cluster instance.
+func flattenClusterInstanceAvailabilityZoneID(name string, availabilityZones []*ec2.AvailabilityZone) string {
+	for _, availabilityZone := range availabilityZones {
+		if availabilityZone != nil && aws.StringValue(availabilityZone.ZoneName) == name {
+			return aws.StringValue(availabilityZone.ZoneId)
+		}
+	}
+
+	return ""
+}
+
+// flattenClusterInstanceEndpoint returns the endpoint of a cluster instance.
+// Shortly after an instance is created the API may not yet report its endpoint. In that case
+// the reader endpoint of its cluster, which routes to the cluster's instances, is used.
+func flattenClusterInstanceEndpoint(dbInstance *rds.DBInstance, dbCluster *rds.DBCluster) *rds.Endpoint {
+	if v := dbInstance.Endpoint; v != nil && aws.StringValue(v.Address) != "" {
+		return v
+	}
+
+	if dbCluster == nil || aws.StringValue(dbCluster.ReaderEndpoint) == "" {
+		return dbInstance.Endpoint
+	}
+
+	return &rds.Endpoint{
+		Address:      dbCluster.ReaderEndpoint,
+		HostedZoneId: dbCluster.HostedZoneId,
+		Port:         dbCluster.Port,
+	}
+}
+
+// flattenClusterInstancePort returns the port of a cluster instance's endpoint. While neither the instance's nor
+// its cluster's endpoint is reported, e.g. shortly after the instance is created, the current port is kept
+// or, if there's none, the default port of the engine is used.
+func flattenClusterInstancePort(current int, endpoint *rds.Endpoint, engine string) int {
+	if endpoint != nil && aws.Int64Value(endpoint.Port) != 0 {
+		return int(aws.Int64Value(endpoint.Port))
+	}
+
+	if current != 0 {
+		return current
+	}
+
+	switch engine {
+	case EngineAurora, EngineAuroraMySQL, EngineMySQL:
+		return enginePortMySQLDefault
+	case EngineAuroraPostgreSQL, EnginePostgres:
+		return enginePortPostgreSQLDefault
+	}
+
+	return 0
+}
+
+// flattenClusterInstanceWriterEndpoint returns the endpoint of a cluster's writer: the instance's own endpoint when it
+// is the writer, otherwise the cluster endpoint, which routes to the writer.
+func flattenClusterInstanceWriterEndpoint(id string, endpoint *rds.Endpoint, dbCluster *rds.DBCluster) string {
+	if dbCluster == nil {
+		return ""
+	}
+
+	for _, v := range dbCluster.DBClusterMembers {
+		if v != nil && aws.StringValue(v.DBInstanceIdentifier) == id && aws.BoolValue(v.IsClusterWriter) && endpoint != nil && aws.StringValue(endpoint.Address) != "" {
+			return aws.StringValue(endpoint.Address)
+		}
+	}
+
+	return aws.StringValue(dbCluster.Endpoint)
+}
+
+// flattenClusterInstanceWriteForwardingEnabled returns whether the cluster of a cluster instance forwards writes
+// to the primary cluster of its Aurora global database. The status is preferred over the requested setting,
+// which is only used when the status isn't reported.
+func flattenClusterInstanceWriteForwardingEnabled(dbCluster *rds.DBCluster) bool {
+	if dbCluster == nil {
+		return false
+	}
+
+	if v := dbCluster.GlobalWriteForwardingStatus; v != nil {
+		return aws.StringValue(v) == rds.WriteForwardingStatusEnabled
+	}
+
+	return aws.BoolValue(dbCluster.GlobalWriteForwardingRequested)
+}
+
+// flattenClusterInstancePerformanceInsightsRetentionPeriod returns the Performance Insights retention period of a cluster instance.
+// While Performance Insights is being enabled the API may report it as enabled with a retention period of 0. In that case
+// the previously known value is kept or, if there is none, the default retention period is used.
+func flattenClusterInstancePerformanceInsightsRetentionPeriod(current int, dbInstance *rds.DBInstance) int {
+	if v := aws.Int64Value(dbInstance.PerformanceInsightsRetentionPeriod); v > 0 || !aws.BoolValue(dbInstance.PerformanceInsightsEnabled) {
+		return int(v)
+	}
+
+	if current > 0 {
+		return current
+	}
+
+	return performanceInsightsRetentionPeriodDefault
+}
+
+// flattenClusterInstanceClusterIAMRoles returns the IAM roles associated with the cluster of a cluster instance.
+func flattenClusterInstanceClusterIAMRoles(apiObjects []*rds.DBClusterRole) []interface{} {
+	var tfList []interface{}
+
+	for _, apiObject := range apiObjects {
+		if apiObject == nil {
+			continue
+		}
+
+		tfList = append(tfList, map[string]interface{}{
+			"feature_name": aws.StringValue(apiObject.FeatureName),
+			"role_arn":     aws.StringValue(apiObject.RoleArn),
+		})
+	}
+
+	return tfList
+}
+
+func flattenClusterInstanceStatusInfos(apiObjects []*rds.DBInstanceStatusInfo) []interface{} {
+	var tfList []interface{}
+
+	for _, apiObject := range apiObjects {
+		if apiObject == nil {
+			continue
+		}
+
+		tfList = append(tfList, map[string]interface{}{
+			"message":     aws.StringValue(apiObject.Message),
+			"normal":      aws.BoolValue(apiObject.Normal),
+			"status":      aws.StringValue(apiObject.Status),
+			"status_type": aws.StringValue(apiObject.StatusType),
+		})
+	}
+
+	return tfList
+}
+
+func flattenClusterInstancePendingMaintenanceActions(apiObjects []*rds.PendingMaintenanceAction) []interface{} {
+	var tfList []interface{}
+
+	for _, apiObject := range apiObjects {
+		if apiObject == nil {
+			continue
+		}
+
+		tfList = append(tfList, map[string]interface{}{
+			"action":                  aws.StringValue(apiObject.Action),
+			"auto_applied_after_date": flattenClusterInstanceMaintenanceDate(apiObject.AutoAppliedAfterDate),
+			"current_apply_date":      flattenClusterInstanceMaintenanceDate(apiObject.CurrentApplyDate),
+			"description":             aws.StringValue(apiObject.Description),
+			"forced_apply_date":       flattenClusterInstanceMaintenanceDate(apiObject.ForcedApplyDate),
+			"opt_in_status":           aws.StringValue(apiObject.OptInStatus),
+		})
+	}
+
+	return tfList
+}
+
+// flattenClusterInstanceMaintenanceDate returns the specified date of a pending maintenance action
+// in RFC3339 format, or an empty string if the action has no such date.
+func flattenClusterInstanceMaintenanceDate(v *time.Time) string {
+	if v == nil {
+		return ""
+	}
+
+	return aws.TimeValue(v).Format(time.RFC3339)
+}
+
+// flattenClusterInstanceEngineVersionMatchesCluster returns whether a cluster instance runs the engine version of its
+// cluster, or a patch-level version of it, the same comparison as used for the configured engine_version.
+func flattenClusterInstanceEngineVersionMatchesCluster(dbInstance *rds.DBInstance, dbCluster *rds.DBCluster) bool {
+	if dbCluster == nil {
+		return false
+	}
+
+	return engineVersionSatisfies(aws.StringValue(dbCluster.EngineVersion), aws.StringValue(dbInstance.EngineVersion))
+}
+
+// flattenClusterInstanceReadReplicaDBInstanceIdentifiers returns the identifiers of the read replicas of a cluster instance.
+// Aurora DB instances don't have read replicas of their own, replication is between DB clusters.
+func flattenClusterInstanceReadReplicaDBInstanceIdentifiers(dbInstance *rds.DBInstance) []string {
+	if isAuroraEngine(aws.StringValue(dbInstance.Engine)) {
+		return nil
+	}
+
+	return aws.StringValueSlice(dbInstance.ReadReplicaDBInstanceIdentifiers)
+}
+
+// flattenClusterInstanceOutpostARNs returns the ARNs of the Outposts of the subnets in a cluster instance's DB subnet group.
+// The DB subnet groups of DB instances that aren't on Outposts have no Outpost subnets.
+func flattenClusterInstanceOutpostARNs(dbInstance *rds.DBInstance) []string {
+	if dbInstance.DBSubnetGroup == nil {
+		return nil
+	}
+
+	var arns []string
+	seen := make(map[string]struct{})
+
+	for _, v := range dbInstance.DBSubnetGroup.Subnets {
+		if v == nil || v.SubnetOutpost == nil {
+			continue
+		}
+
+		arn := aws.StringValue(v.SubnetOutpost.Arn)
+
+		if _, ok := seen[arn]; arn == "" || ok {
+			continue
+		}
+
+		seen[arn] = struct{}{}
+		arns = append(arns, arn)
+	}
+
+	return arns
+}
+
+// flattenClusterInstanceInstanceClassFamily returns the family of an instance class, e.g. "db.r6g" for "db.r6g.2xlarge".
+// The family of db.serverless is "serverless".
+func flattenClusterInstanceInstanceClassFamily(instanceClass string) string {
+	if instanceClass == instanceClassServerless {
+		return "serverless"
+	}
+
+	// The size can have further suffixes, e.g. "db.r5.4xlarge.tpc2.mem3x".
+	if parts := strings.SplitN(instanceClass, ".", 3); len(parts) == 3 {
+		return parts[0] + "." + parts[1]
+	}
+
+	return instanceClass
+}
+
+// flattenClusterInstanceCACertKeyType returns the key type of a CA certificate, derived from its identifier,
+// e.g. "ECDSA" for "rds-ca-ecc384-g1" and "RSA" for "rds-ca-rsa2048-g1". The key type of an unknown identifier is "".
+func flattenClusterInstanceCACertKeyType(caCertIdentifier string) string {
+	id := strings.ToLower(caCertIdentifier)
+
+	if !strings.HasPrefix(id, "rds-ca-") {
+		return ""
+	}
+
+	if strings.Contains(id, "-ecc") {
+		return caCertKeyTypeECDSA
+	}
+
+	if strings.Contains(id, "-rsa") {
+		return caCertKeyTypeRSA
+	}
+
+	// The CA certificates before the rsa and ecc families, e.g. rds-ca-2019, have RSA keys.
+	if _, err := strconv.Atoi(strings.TrimPrefix(id, "rds-ca-")); err == nil {
+		return caCertKeyTypeRSA
+	}
+
+	return ""
+}
+
+// flattenClusterInstanceNeedsReboot returns whether a cluster instance has pending modifications or
+// a DB parameter group whose changes are only applied once the instance is rebooted.
+func flattenClusterInstanceNeedsReboot(dbInstance *rds.DBInstance) bool {
+	if dbInstanceHasPendingModifiedValues(dbInstance) {
+		return true
+	}
+
+	for _, v := range dbInstance.DBParameterGroups {
+		if v != nil && aws.StringValue(v.ParameterApplyStatus) == parameterApplyStatusPendingReboot {
+			return true
+		}
+	}
+
+	return false
+}
+
+// flattenCertificateExpiringSoon returns whether the specified certificate expires within caCertificateExpiringSoonThreshold of now.
+func flattenCertificateExpiringSoon(certificate *rds.Certificate, now time.Time) bool {
+	if certificate == nil || certificate.ValidTill == nil {
+		return false
+	}
+
+	return aws.TimeValue(certificate.ValidTill).Before(now.Add(caCertificateExpiringSoonThreshold))
+}
+
+const (
+	minutesPerDay  = 24 * 60
+	minutesPerWeek = 7 * minutesPerDay
+)
+
+// flattenClusterInstanceBackupWindowOverlapsMaintenance returns whether a cluster instance's weekly maintenance window,
+// e.g. "sun:05:00-sun:06:00", overlaps its cluster's daily backup window, e.g. "04:30-05:30". Windows that can't be
+// parsed don't overlap.
+func flattenClusterInstanceBackupWindowOverlapsMaintenance(maintenanceWindow, backupWindow string) bool {
+	maintenanceStart, maintenanceEnd, ok := parseClusterInstanceWindow(maintenanceWindow, true)
+
+	if !ok {
+		return false
+	}
+
+	backupStart, backupEnd, ok := parseClusterInstanceWindow(backupWindow, false)
+
+	if !ok {
+		return false
+	}
+
+	maintenanceLength := (maintenanceEnd - maintenanceStart + minutesPerWeek) % minutesPerWeek
+	backupLength := (backupEnd - backupStart + minutesPerDay) % minutesPerDay
+
+	// The backup window recurs daily, the maintenance window weekly. Both can wrap around.
+	for day := 0; day < 7; day++ {
+		start := day*minutesPerDay + backupStart
+
+		if (start-maintenanceStart+minutesPerWeek)%minutesPerWeek < maintenanceLength || (maintenanceStart-start+minutesPerWeek)%minutesPerWeek < backupLength {
+			return true
+		}
+	}
+
+	return false
+}
+
+// parseClusterInstanceWindow returns the start and end of a "hh24:mi-hh24:mi" window in minutes of the day or,
+// when weekly, of a "ddd:hh24:mi-ddd:hh24:mi" window in minutes of the week starting on Monday.
+func parseClusterInstanceWindow(window string, weekly bool) (int, int, bool) {
+	parts := strings.Split(strings.ToLower(window), "-")
+
+	if len(parts) != 2 {
+		return 0, 0, false
+	}
+
+	start, ok := parseClusterInstanceWindowTime(parts[0], weekly)
+
+	if !ok {
+		return 0, 0, false
+	}
+
+	end, ok := parseClusterInstanceWindowTime(parts[1], weekly)
+
+	if !ok {
+		return 0, 0, false
+	}
+
+	return start, end, true
+}
+
+func parseClusterInstanceWindowTime(v string, weekly bool) (int, bool) {
+	parts := strings.Split(v, ":")
+	var minutes int
+
+	if weekly {
+		if len(parts) != 3 {
+			return 0, false
+		}
+
+		day := -1
+		for i, v := range []string{"mon", "tue", "wed", "thu", "fri", "sat", "sun"} {
+			if v == parts[0] {
+				day = i
+			}
+		}
+
+		if day < 0 {
+			return 0, false
+		}
+
+		minutes, parts = day*minutesPerDay, parts[1:]
+	}
+
+	if len(parts) != 2 {
+		return 0, false
+	}
+
+	hours, err := strconv.Atoi(parts[0])
+
+	if err != nil || hours < 0 || hours > 23 {
+		return 0, false
+	}
+
+	mins, err := strconv.Atoi(parts[1])
+
+	if err != nil || mins < 0 || mins > 59 {
+		return 0, false
+	}
+
+	return minutes + hours*60 + mins, true
+}
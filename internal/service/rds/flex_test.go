@@ -3,8 +3,10 @@ package rds
 import (
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/rds"
 )
 
@@ -127,3 +129,766 @@ func TestExpandClusterScalingConfiguration_basic(t *testing.T) {
 		}
 	}
 }
+
+func TestFlattenClusterInstanceAvailabilityZone(t *testing.T) {
+	cases := map[string]struct {
+		Current    string
+		DBInstance *rds.DBInstance
+		DBCluster  *rds.DBCluster
+		Expected   string
+	}{
+		"reported by instance": {
+			Current: "us-west-2b",
+			DBInstance: &rds.DBInstance{
+				AvailabilityZone: aws.String("us-west-2a"),
+			},
+			DBCluster: &rds.DBCluster{},
+			Expected:  "us-west-2a",
+		},
+		"empty keeps current": {
+			Current:    "us-west-2b",
+			DBInstance: &rds.DBInstance{},
+			DBCluster: &rds.DBCluster{
+				AvailabilityZones: aws.StringSlice([]string{"us-west-2a"}),
+			},
+			Expected: "us-west-2b",
+		},
+		"empty from single-AZ subnet group": {
+			DBInstance: &rds.DBInstance{
+				DBSubnetGroup: &rds.DBSubnetGroup{
+					Subnets: []*rds.Subnet{
+						{SubnetAvailabilityZone: &rds.AvailabilityZone{Name: aws.String("us-west-2c")}},
+						{SubnetAvailabilityZone: &rds.AvailabilityZone{Name: aws.String("us-west-2c")}},
+					},
+				},
+			},
+			DBCluster: &rds.DBCluster{},
+			Expected:  "us-west-2c",
+		},
+		"empty from single-AZ cluster": {
+			DBInstance: &rds.DBInstance{
+				DBSubnetGroup: &rds.DBSubnetGroup{
+					Subnets: []*rds.Subnet{
+						{SubnetAvailabilityZone: &rds.AvailabilityZone{Name: aws.String("us-west-2a")}},
+						{SubnetAvailabilityZone: &rds.AvailabilityZone{Name: aws.String("us-west-2b")}},
+					},
+				},
+			},
+			DBCluster: &rds.DBCluster{
+				AvailabilityZones: aws.StringSlice([]string{"us-west-2b"}),
+			},
+			Expected: "us-west-2b",
+		},
+		"empty and ambiguous": {
+			DBInstance: &rds.DBInstance{},
+			DBCluster: &rds.DBCluster{
+				AvailabilityZones: aws.StringSlice([]string{"us-west-2a", "us-west-2b", "us-west-2c"}),
+			},
+			Expected: "",
+		},
+	}
+
+	for name, tc := range cases {
+		if got := flattenClusterInstanceAvailabilityZone(tc.Current, tc.DBInstance, tc.DBCluster); got != tc.Expected {
+			t.Errorf("%s: got %q, expected %q", name, got, tc.Expected)
+		}
+	}
+}
+
+func TestFlattenClusterInstanceAvailabilityZoneID(t *testing.T) {
+	availabilityZones := []*ec2.AvailabilityZone{
+		{ZoneName: aws.String("us-west-2a"), ZoneId: aws.String("usw2-az2")},
+		nil,
+		{ZoneName: aws.String("us-west-2b"), ZoneId: aws.String("usw2-az1")},
+	}
+
+	cases := map[string]struct {
+		Name     string
+		Expected string
+	}{
+		"mapped": {
+			Name:     "us-west-2b",
+			Expected: "usw2-az1",
+		},
+		"not mapped": {
+			Name:     "us-west-2d",
+			Expected: "",
+		},
+	}
+
+	for name, tc := range cases {
+		if got := flattenClusterInstanceAvailabilityZoneID(tc.Name, availabilityZones); got != tc.Expected {
+			t.Errorf("%s: got %q, expected %q", name, got, tc.Expected)
+		}
+	}
+}
+
+func TestFlattenClusterInstanceEndpoint(t *testing.T) {
+	instanceEndpoint := &rds.Endpoint{
+		Address:      aws.String("tf-test.cluster-instance.us-west-2.rds.amazonaws.com"),
+		HostedZoneId: aws.String("Z1PVIF0B656C1W"),
+		Port:         aws.Int64(3306),
+	}
+	dbCluster := &rds.DBCluster{
+		HostedZoneId:   aws.String("Z1PVIF0B656C1W"),
+		Port:           aws.Int64(3306),
+		ReaderEndpoint: aws.String("tf-test.cluster-ro-abc.us-west-2.rds.amazonaws.com"),
+	}
+
+	cases := map[string]struct {
+		DBInstance *rds.DBInstance
+		DBCluster  *rds.DBCluster
+		Expected   *rds.Endpoint
+	}{
+		"reported by instance": {
+			DBInstance: &rds.DBInstance{
+				Endpoint: instanceEndpoint,
+			},
+			DBCluster: dbCluster,
+			Expected:  instanceEndpoint,
+		},
+		"lagging from cluster reader endpoint": {
+			DBInstance: &rds.DBInstance{},
+			DBCluster:  dbCluster,
+			Expected: &rds.Endpoint{
+				Address:      aws.String("tf-test.cluster-ro-abc.us-west-2.rds.amazonaws.com"),
+				HostedZoneId: aws.String("Z1PVIF0B656C1W"),
+				Port:         aws.Int64(3306),
+			},
+		},
+		"lagging without address from cluster reader endpoint": {
+			DBInstance: &rds.DBInstance{
+				Endpoint: &rds.Endpoint{},
+			},
+			DBCluster: dbCluster,
+			Expected: &rds.Endpoint{
+				Address:      aws.String("tf-test.cluster-ro-abc.us-west-2.rds.amazonaws.com"),
+				HostedZoneId: aws.String("Z1PVIF0B656C1W"),
+				Port:         aws.Int64(3306),
+			},
+		},
+		"lagging and no cluster reader endpoint": {
+			DBInstance: &rds.DBInstance{},
+			DBCluster:  &rds.DBCluster{},
+			Expected:   nil,
+		},
+	}
+
+	for name, tc := range cases {
+		if got := flattenClusterInstanceEndpoint(tc.DBInstance, tc.DBCluster); !reflect.DeepEqual(got, tc.Expected) {
+			t.Errorf("%s: got %s, expected %s", name, got, tc.Expected)
+		}
+	}
+}
+
+func TestFlattenClusterInstancePerformanceInsightsRetentionPeriod(t *testing.T) {
+	cases := map[string]struct {
+		Current    int
+		DBInstance *rds.DBInstance
+		Expected   int
+	}{
+		"disabled": {
+			Current:    0,
+			DBInstance: &rds.DBInstance{PerformanceInsightsEnabled: aws.Bool(false)},
+			Expected:   0,
+		},
+		"enabled": {
+			Current: 7,
+			DBInstance: &rds.DBInstance{
+				PerformanceInsightsEnabled:         aws.Bool(true),
+				PerformanceInsightsRetentionPeriod: aws.Int64(731),
+			},
+			Expected: 731,
+		},
+		"enabled transient zero retention with current": {
+			Current: 731,
+			DBInstance: &rds.DBInstance{
+				PerformanceInsightsEnabled:         aws.Bool(true),
+				PerformanceInsightsRetentionPeriod: aws.Int64(0),
+			},
+			Expected: 731,
+		},
+		"enabled transient zero retention without current": {
+			Current: 0,
+			DBInstance: &rds.DBInstance{
+				PerformanceInsightsEnabled: aws.Bool(true),
+			},
+			Expected: performanceInsightsRetentionPeriodDefault,
+		},
+	}
+
+	for name, tc := range cases {
+		if got := flattenClusterInstancePerformanceInsightsRetentionPeriod(tc.Current, tc.DBInstance); got != tc.Expected {
+			t.Errorf("%s: got %d, expected %d", name, got, tc.Expected)
+		}
+	}
+}
+
+func TestFlattenCertificateExpiringSoon(t *testing.T) {
+	now := time.Date(2022, time.July, 1, 0, 0, 0, 0, time.UTC)
+
+	cases := map[string]struct {
+		Certificate *rds.Certificate
+		Expected    bool
+	}{
+		"nil": {
+			Certificate: nil,
+			Expected:    false,
+		},
+		"no valid till": {
+			Certificate: &rds.Certificate{},
+			Expected:    false,
+		},
+		"expired": {
+			Certificate: &rds.Certificate{
+				ValidTill: aws.Time(now.Add(-24 * time.Hour)),
+			},
+			Expected: true,
+		},
+		"within threshold": {
+			Certificate: &rds.Certificate{
+				ValidTill: aws.Time(now.Add(caCertificateExpiringSoonThreshold - time.Hour)),
+			},
+			Expected: true,
+		},
+		"outside threshold": {
+			Certificate: &rds.Certificate{
+				ValidTill: aws.Time(now.Add(caCertificateExpiringSoonThreshold + time.Hour)),
+			},
+			Expected: false,
+		},
+	}
+
+	for name, tc := range cases {
+		if got := flattenCertificateExpiringSoon(tc.Certificate, now); got != tc.Expected {
+			t.Errorf("%s: got %t, expected %t", name, got, tc.Expected)
+		}
+	}
+}
+
+func TestFlattenClusterInstanceWriteForwardingEnabled(t *testing.T) {
+	cases := map[string]struct {
+		Cluster  *rds.DBCluster
+		Expected bool
+	}{
+		"nil": {
+			Cluster:  nil,
+			Expected: false,
+		},
+		"not reported": {
+			Cluster:  &rds.DBCluster{},
+			Expected: false,
+		},
+		"requested without status": {
+			Cluster: &rds.DBCluster{
+				GlobalWriteForwardingRequested: aws.Bool(true),
+			},
+			Expected: true,
+		},
+		"enabled": {
+			Cluster: &rds.DBCluster{
+				GlobalWriteForwardingRequested: aws.Bool(true),
+				GlobalWriteForwardingStatus:    aws.String(rds.WriteForwardingStatusEnabled),
+			},
+			Expected: true,
+		},
+		"enabling": {
+			Cluster: &rds.DBCluster{
+				GlobalWriteForwardingRequested: aws.Bool(true),
+				GlobalWriteForwardingStatus:    aws.String(rds.WriteForwardingStatusEnabling),
+			},
+			Expected: false,
+		},
+		"disabled": {
+			Cluster: &rds.DBCluster{
+				GlobalWriteForwardingStatus: aws.String(rds.WriteForwardingStatusDisabled),
+			},
+			Expected: false,
+		},
+	}
+
+	for name, tc := range cases {
+		if got := flattenClusterInstanceWriteForwardingEnabled(tc.Cluster); got != tc.Expected {
+			t.Errorf("%s: got %t, expected %t", name, got, tc.Expected)
+		}
+	}
+}
+
+func TestFlattenClusterInstanceClusterIAMRoles(t *testing.T) {
+	cases := map[string]struct {
+		Roles    []*rds.DBClusterRole
+		Expected []interface{}
+	}{
+		"no roles": {
+			Roles:    nil,
+			Expected: nil,
+		},
+		"roles": {
+			Roles: []*rds.DBClusterRole{
+				{
+					FeatureName: aws.String("s3Import"),
+					RoleArn:     aws.String("arn:aws:iam::123456789012:role/s3-import"),
+					Status:      aws.String("ACTIVE"),
+				},
+				nil,
+				{
+					RoleArn: aws.String("arn:aws:iam::123456789012:role/legacy"),
+					Status:  aws.String("ACTIVE"),
+				},
+			},
+			Expected: []interface{}{
+				map[string]interface{}{
+					"feature_name": "s3Import",
+					"role_arn":     "arn:aws:iam::123456789012:role/s3-import",
+				},
+				map[string]interface{}{
+					"feature_name": "",
+					"role_arn":     "arn:aws:iam::123456789012:role/legacy",
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		if got := flattenClusterInstanceClusterIAMRoles(tc.Roles); !reflect.DeepEqual(got, tc.Expected) {
+			t.Errorf("%s: got %#v, expected %#v", name, got, tc.Expected)
+		}
+	}
+}
+
+func TestFlattenClusterInstanceNeedsReboot(t *testing.T) {
+	cases := map[string]struct {
+		DBInstance *rds.DBInstance
+		Expected   bool
+	}{
+		"in sync": {
+			DBInstance: &rds.DBInstance{
+				DBParameterGroups: []*rds.DBParameterGroupStatus{
+					{DBParameterGroupName: aws.String("default.aurora-postgresql13"), ParameterApplyStatus: aws.String("in-sync")},
+				},
+				PendingModifiedValues: &rds.PendingModifiedValues{},
+			},
+			Expected: false,
+		},
+		"pending modified values": {
+			DBInstance: &rds.DBInstance{
+				PendingModifiedValues: &rds.PendingModifiedValues{
+					DBInstanceClass: aws.String("db.r6g.large"),
+				},
+			},
+			Expected: true,
+		},
+		"parameter group pending reboot": {
+			DBInstance: &rds.DBInstance{
+				DBParameterGroups: []*rds.DBParameterGroupStatus{
+					{DBParameterGroupName: aws.String("test"), ParameterApplyStatus: aws.String("pending-reboot")},
+				},
+			},
+			Expected: true,
+		},
+		"parameter group applying": {
+			DBInstance: &rds.DBInstance{
+				DBParameterGroups: []*rds.DBParameterGroupStatus{
+					{DBParameterGroupName: aws.String("test"), ParameterApplyStatus: aws.String("applying")},
+				},
+			},
+			Expected: false,
+		},
+	}
+
+	for name, tc := range cases {
+		if got := flattenClusterInstanceNeedsReboot(tc.DBInstance); got != tc.Expected {
+			t.Errorf("%s: got %t, expected %t", name, got, tc.Expected)
+		}
+	}
+}
+
+func TestFlattenClusterInstancePendingMaintenanceActions(t *testing.T) {
+	cases := map[string]struct {
+		Actions  []*rds.PendingMaintenanceAction
+		Expected []interface{}
+	}{
+		"no actions": {
+			Actions:  nil,
+			Expected: nil,
+		},
+		"actions": {
+			Actions: []*rds.PendingMaintenanceAction{
+				{
+					Action:           aws.String("system-update"),
+					CurrentApplyDate: aws.Time(time.Date(2022, 12, 19, 4, 0, 0, 0, time.UTC)),
+					Description:      aws.String("New Operating System update is available"),
+					ForcedApplyDate:  aws.Time(time.Date(2023, 1, 31, 0, 0, 0, 0, time.UTC)),
+				},
+				nil,
+				{
+					Action:               aws.String("db-upgrade"),
+					AutoAppliedAfterDate: aws.Time(time.Date(2023, 2, 28, 0, 0, 0, 0, time.UTC)),
+					OptInStatus:          aws.String("next-maintenance"),
+				},
+			},
+			Expected: []interface{}{
+				map[string]interface{}{
+					"action":                  "system-update",
+					"auto_applied_after_date": "",
+					"current_apply_date":      "2022-12-19T04:00:00Z",
+					"description":             "New Operating System update is available",
+					"forced_apply_date":       "2023-01-31T00:00:00Z",
+					"opt_in_status":           "",
+				},
+				map[string]interface{}{
+					"action":                  "db-upgrade",
+					"auto_applied_after_date": "2023-02-28T00:00:00Z",
+					"current_apply_date":      "",
+					"description":             "",
+					"forced_apply_date":       "",
+					"opt_in_status":           "next-maintenance",
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		if got := flattenClusterInstancePendingMaintenanceActions(tc.Actions); !reflect.DeepEqual(got, tc.Expected) {
+			t.Errorf("%s: got %#v, expected %#v", name, got, tc.Expected)
+		}
+	}
+}
+
+func TestFlattenClusterInstanceStatusInfos(t *testing.T) {
+	cases := map[string]struct {
+		StatusInfos []*rds.DBInstanceStatusInfo
+		Expected    []interface{}
+	}{
+		"no status infos": {
+			StatusInfos: nil,
+			Expected:    nil,
+		},
+		"status infos": {
+			StatusInfos: []*rds.DBInstanceStatusInfo{
+				{
+					Normal:     aws.Bool(true),
+					Status:     aws.String("replicating"),
+					StatusType: aws.String("read replication"),
+				},
+				nil,
+				{
+					Message:    aws.String("Replication has stopped."),
+					Normal:     aws.Bool(false),
+					Status:     aws.String("error"),
+					StatusType: aws.String("read replication"),
+				},
+			},
+			Expected: []interface{}{
+				map[string]interface{}{
+					"message":     "",
+					"normal":      true,
+					"status":      "replicating",
+					"status_type": "read replication",
+				},
+				map[string]interface{}{
+					"message":     "Replication has stopped.",
+					"normal":      false,
+					"status":      "error",
+					"status_type": "read replication",
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		if got := flattenClusterInstanceStatusInfos(tc.StatusInfos); !reflect.DeepEqual(got, tc.Expected) {
+			t.Errorf("%s: got %#v, expected %#v", name, got, tc.Expected)
+		}
+	}
+}
+
+func TestFlattenClusterInstancePort(t *testing.T) {
+	cases := map[string]struct {
+		Current  int
+		Endpoint *rds.Endpoint
+		Engine   string
+		Expected int
+	}{
+		"endpoint": {
+			Current:  3306,
+			Endpoint: &rds.Endpoint{Address: aws.String("tf-test.cluster-instance.us-west-2.rds.amazonaws.com"), Port: aws.Int64(3307)},
+			Engine:   EngineAuroraMySQL,
+			Expected: 3307,
+		},
+		"no endpoint current port": {
+			Current:  3307,
+			Engine:   EngineAuroraMySQL,
+			Expected: 3307,
+		},
+		"no endpoint aurora": {
+			Engine:   EngineAurora,
+			Expected: 3306,
+		},
+		"no endpoint aurora-mysql": {
+			Engine:   EngineAuroraMySQL,
+			Expected: 3306,
+		},
+		"no endpoint aurora-postgresql": {
+			Engine:   EngineAuroraPostgreSQL,
+			Expected: 5432,
+		},
+		"endpoint without port": {
+			Endpoint: &rds.Endpoint{},
+			Engine:   EnginePostgres,
+			Expected: 5432,
+		},
+		"no endpoint unknown engine": {
+			Engine:   "custom-oracle-ee",
+			Expected: 0,
+		},
+	}
+
+	for name, tc := range cases {
+		if got := flattenClusterInstancePort(tc.Current, tc.Endpoint, tc.Engine); got != tc.Expected {
+			t.Errorf("%s: got %d, expected %d", name, got, tc.Expected)
+		}
+	}
+}
+
+func TestFlattenClusterInstanceBackupWindowOverlapsMaintenance(t *testing.T) {
+	cases := []struct {
+		MaintenanceWindow string
+		BackupWindow      string
+		Expected          bool
+	}{
+		{MaintenanceWindow: "sun:05:00-sun:06:00", BackupWindow: "03:00-04:00", Expected: false},
+		{MaintenanceWindow: "sun:05:00-sun:06:00", BackupWindow: "04:30-05:30", Expected: true},
+		{MaintenanceWindow: "Sun:05:00-Sun:06:00", BackupWindow: "05:30-06:30", Expected: true},
+		// Adjacent windows don't overlap.
+		{MaintenanceWindow: "sun:05:00-sun:06:00", BackupWindow: "06:00-07:00", Expected: false},
+		{MaintenanceWindow: "sun:05:00-sun:06:00", BackupWindow: "04:00-05:00", Expected: false},
+		// The backup window wraps around midnight.
+		{MaintenanceWindow: "tue:00:10-tue:00:40", BackupWindow: "23:30-00:30", Expected: true},
+		// The maintenance window wraps around the end of the week.
+		{MaintenanceWindow: "sun:23:30-mon:00:30", BackupWindow: "00:15-00:45", Expected: true},
+		// The maintenance window spans days.
+		{MaintenanceWindow: "fri:22:00-sat:02:00", BackupWindow: "01:00-01:30", Expected: true},
+		{MaintenanceWindow: "", BackupWindow: "04:30-05:30", Expected: false},
+		{MaintenanceWindow: "sun:05:00-sun:06:00", BackupWindow: "", Expected: false},
+		{MaintenanceWindow: "xyz:05:00-sun:06:00", BackupWindow: "04:30-05:30", Expected: false},
+		{MaintenanceWindow: "sun:05:00-sun:06:00", BackupWindow: "24:30-05:30", Expected: false},
+	}
+
+	for _, tc := range cases {
+		if got := flattenClusterInstanceBackupWindowOverlapsMaintenance(tc.MaintenanceWindow, tc.BackupWindow); got != tc.Expected {
+			t.Errorf("%q/%q: got %t, expected %t", tc.MaintenanceWindow, tc.BackupWindow, got, tc.Expected)
+		}
+	}
+}
+
+func TestFlattenClusterInstanceInstanceClassFamily(t *testing.T) {
+	cases := map[string]string{
+		"db.r6g.2xlarge":           "db.r6g",
+		"db.r5.large":              "db.r5",
+		"db.t4g.medium":            "db.t4g",
+		"db.x2iedn.16xlarge":       "db.x2iedn",
+		"db.r5.4xlarge.tpc2.mem3x": "db.r5",
+		"db.serverless":            "serverless",
+		"":                         "",
+	}
+
+	for instanceClass, expected := range cases {
+		if got := flattenClusterInstanceInstanceClassFamily(instanceClass); got != expected {
+			t.Errorf("%q: got %q, expected %q", instanceClass, got, expected)
+		}
+	}
+}
+
+func TestFlattenClusterInstanceCACertKeyType(t *testing.T) {
+	cases := map[string]string{
+		"rds-ca-rsa2048-g1": "RSA",
+		"rds-ca-rsa4096-g1": "RSA",
+		"rds-ca-ecc384-g1":  "ECDSA",
+		"RDS-CA-ECC384-G1":  "ECDSA",
+		"rds-ca-2019":       "RSA",
+		"rds-ca-2015":       "RSA",
+		"rds-ca-custom":     "",
+		"custom-ca-ecc":     "",
+		"":                  "",
+	}
+
+	for caCertIdentifier, expected := range cases {
+		if got := flattenClusterInstanceCACertKeyType(caCertIdentifier); got != expected {
+			t.Errorf("%q: got %q, expected %q", caCertIdentifier, got, expected)
+		}
+	}
+}
+
+func TestFlattenClusterInstanceEngineVersionMatchesCluster(t *testing.T) {
+	cases := map[string]struct {
+		InstanceEngineVersion string
+		ClusterEngineVersion  string
+		Expected              bool
+	}{
+		"match": {
+			InstanceEngineVersion: "8.0.mysql_aurora.3.02.0",
+			ClusterEngineVersion:  "8.0.mysql_aurora.3.02.0",
+			Expected:              true,
+		},
+		"instance lagging": {
+			InstanceEngineVersion: "13.6",
+			ClusterEngineVersion:  "13.7",
+			Expected:              false,
+		},
+		"instance ahead": {
+			InstanceEngineVersion: "8.0.mysql_aurora.3.02.1",
+			ClusterEngineVersion:  "8.0.mysql_aurora.3.02.0",
+			Expected:              false,
+		},
+		"patch level": {
+			InstanceEngineVersion: "5.7.mysql_aurora.2.10.2",
+			ClusterEngineVersion:  "5.7.mysql_aurora.2.10",
+			Expected:              true,
+		},
+	}
+
+	for name, tc := range cases {
+		dbInstance := &rds.DBInstance{EngineVersion: aws.String(tc.InstanceEngineVersion)}
+		dbCluster := &rds.DBCluster{EngineVersion: aws.String(tc.ClusterEngineVersion)}
+
+		if got := flattenClusterInstanceEngineVersionMatchesCluster(dbInstance, dbCluster); got != tc.Expected {
+			t.Errorf("%s: got %t, expected %t", name, got, tc.Expected)
+		}
+	}
+
+	if flattenClusterInstanceEngineVersionMatchesCluster(&rds.DBInstance{EngineVersion: aws.String("13.7")}, nil) {
+		t.Error("no cluster: got true, expected false")
+	}
+}
+
+func TestFlattenClusterInstanceReadReplicaDBInstanceIdentifiers(t *testing.T) {
+	cases := map[string]struct {
+		DBInstance *rds.DBInstance
+		Expected   []string
+	}{
+		"replicas": {
+			DBInstance: &rds.DBInstance{
+				Engine:                           aws.String(EnginePostgres),
+				ReadReplicaDBInstanceIdentifiers: aws.StringSlice([]string{"tf-test-replica-1", "tf-test-replica-2"}),
+			},
+			Expected: []string{"tf-test-replica-1", "tf-test-replica-2"},
+		},
+		"no replicas": {
+			DBInstance: &rds.DBInstance{
+				Engine:                           aws.String(EngineMySQL),
+				ReadReplicaDBInstanceIdentifiers: []*string{},
+			},
+			Expected: []string{},
+		},
+		"aurora": {
+			DBInstance: &rds.DBInstance{
+				Engine:                           aws.String(EngineAuroraMySQL),
+				ReadReplicaDBInstanceIdentifiers: []*string{},
+			},
+			Expected: nil,
+		},
+	}
+
+	for name, tc := range cases {
+		if got := flattenClusterInstanceReadReplicaDBInstanceIdentifiers(tc.DBInstance); !reflect.DeepEqual(got, tc.Expected) {
+			t.Errorf("%s: got %#v, expected %#v", name, got, tc.Expected)
+		}
+	}
+}
+
+func TestFlattenClusterInstanceWriterEndpoint(t *testing.T) {
+	instanceEndpoint := &rds.Endpoint{
+		Address: aws.String("tf-test.cluster-instance.us-west-2.rds.amazonaws.com"),
+		Port:    aws.Int64(3306),
+	}
+	dbCluster := &rds.DBCluster{
+		DBClusterMembers: []*rds.DBClusterMember{
+			{DBInstanceIdentifier: aws.String("writer"), IsClusterWriter: aws.Bool(true)},
+			{DBInstanceIdentifier: aws.String("reader"), IsClusterWriter: aws.Bool(false)},
+		},
+		Endpoint: aws.String("tf-test.cluster-abc.us-west-2.rds.amazonaws.com"),
+	}
+
+	cases := map[string]struct {
+		ID        string
+		Endpoint  *rds.Endpoint
+		DBCluster *rds.DBCluster
+		Expected  string
+	}{
+		"writer": {
+			ID:        "writer",
+			Endpoint:  instanceEndpoint,
+			DBCluster: dbCluster,
+			Expected:  "tf-test.cluster-instance.us-west-2.rds.amazonaws.com",
+		},
+		"writer without endpoint": {
+			ID:        "writer",
+			DBCluster: dbCluster,
+			Expected:  "tf-test.cluster-abc.us-west-2.rds.amazonaws.com",
+		},
+		"reader": {
+			ID:        "reader",
+			Endpoint:  instanceEndpoint,
+			DBCluster: dbCluster,
+			Expected:  "tf-test.cluster-abc.us-west-2.rds.amazonaws.com",
+		},
+		"no cluster": {
+			ID:       "writer",
+			Endpoint: instanceEndpoint,
+			Expected: "",
+		},
+	}
+
+	for name, tc := range cases {
+		if got := flattenClusterInstanceWriterEndpoint(tc.ID, tc.Endpoint, tc.DBCluster); got != tc.Expected {
+			t.Errorf("%s: got %q, expected %q", name, got, tc.Expected)
+		}
+	}
+}
+
+func TestFlattenClusterInstanceOutpostARNs(t *testing.T) {
+	outpostARN := "arn:aws:outposts:us-west-2:123456789012:outpost/op-0123456789abcdef0"
+
+	cases := map[string]struct {
+		DBInstance *rds.DBInstance
+		Expected   []string
+	}{
+		"outposts": {
+			DBInstance: &rds.DBInstance{
+				BackupTarget:           aws.String("outposts"),
+				CustomerOwnedIpEnabled: aws.Bool(true),
+				DBSubnetGroup: &rds.DBSubnetGroup{
+					Subnets: []*rds.Subnet{
+						{SubnetIdentifier: aws.String("subnet-1"), SubnetOutpost: &rds.Outpost{Arn: aws.String(outpostARN)}},
+						{SubnetIdentifier: aws.String("subnet-2"), SubnetOutpost: &rds.Outpost{Arn: aws.String(outpostARN)}},
+						nil,
+					},
+				},
+			},
+			Expected: []string{outpostARN},
+		},
+		"region": {
+			DBInstance: &rds.DBInstance{
+				BackupTarget: aws.String("region"),
+				DBSubnetGroup: &rds.DBSubnetGroup{
+					Subnets: []*rds.Subnet{
+						{SubnetIdentifier: aws.String("subnet-1"), SubnetOutpost: &rds.Outpost{}},
+						{SubnetIdentifier: aws.String("subnet-2")},
+					},
+				},
+			},
+			Expected: nil,
+		},
+		"no subnet group": {
+			DBInstance: &rds.DBInstance{},
+			Expected:   nil,
+		},
+	}
+
+	for name, tc := range cases {
+		if got := flattenClusterInstanceOutpostARNs(tc.DBInstance); !reflect.DeepEqual(got, tc.Expected) {
+			t.Errorf("%s: got %#v, expected %#v", name, got, tc.Expected)
+		}
+	}
+}
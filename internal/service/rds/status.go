@@ -1,11 +1,16 @@
 package rds
 
 import (
+	"fmt"
+	"reflect"
 	"strconv"
+	"strings"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/rds"
+	"github.com/aws/aws-sdk-go/service/rds/rdsiface"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-provider-aws/internal/flex"
 	"github.com/hashicorp/terraform-provider-aws/internal/tfresource"
 )
 
@@ -66,7 +71,7 @@ func statusDBClusterRole(conn *rds.RDS, dbClusterID, roleARN string) resource.St
 	}
 }
 
-func statusDBInstance(conn *rds.RDS, id string) resource.StateRefreshFunc {
+func statusDBInstance(conn rdsiface.RDSAPI, id string) resource.StateRefreshFunc {
 	return func() (interface{}, string, error) {
 		output, err := FindDBInstanceByID(conn, id)
 
@@ -82,6 +87,56 @@ func statusDBInstance(conn *rds.RDS, id string) resource.StateRefreshFunc {
 	}
 }
 
+// statusDBInstanceRecordState wraps a DB instance refresh function, setting entered to true once
+// the DB instance reports the specified state.
+func statusDBInstanceRecordState(refresh resource.StateRefreshFunc, state string, entered *bool) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		outputRaw, status, err := refresh()
+
+		if err == nil && outputRaw != nil && status == state {
+			*entered = true
+		}
+
+		return outputRaw, status, err
+	}
+}
+
+// statusDBInstanceFailOnStates wraps a DB instance refresh function, returning an error as soon as
+// the DB instance enters one of the specified states, from which it won't reach the target state.
+func statusDBInstanceFailOnStates(refresh resource.StateRefreshFunc, states ...string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		outputRaw, status, err := refresh()
+
+		if err != nil || outputRaw == nil {
+			return outputRaw, status, err
+		}
+
+		for _, v := range states {
+			if status != v {
+				continue
+			}
+
+			var messages []string
+
+			if output, ok := outputRaw.(*rds.DBInstance); ok {
+				for _, statusInfo := range output.StatusInfos {
+					if v := aws.StringValue(statusInfo.Message); v != "" {
+						messages = append(messages, v)
+					}
+				}
+			}
+
+			if len(messages) == 0 {
+				return outputRaw, status, fmt.Errorf("DB instance entered state %q", status)
+			}
+
+			return outputRaw, status, fmt.Errorf("DB instance entered state %q: %s", status, strings.Join(messages, "; "))
+		}
+
+		return outputRaw, status, nil
+	}
+}
+
 func statusDBClusterActivityStream(conn *rds.RDS, dbClusterArn string) resource.StateRefreshFunc {
 	return func() (interface{}, string, error) {
 		output, err := FindDBClusterWithActivityStream(conn, dbClusterArn)
@@ -142,6 +197,155 @@ func statusDBInstanceHasAutomatedBackup(conn *rds.RDS, dbInstanceID, dbInstanceA
 	}
 }
 
+func dbInstanceHasPendingModifiedValues(dbInstance *rds.DBInstance) bool {
+	if dbInstance.PendingModifiedValues == nil {
+		return false
+	}
+
+	return !reflect.DeepEqual(*dbInstance.PendingModifiedValues, rds.PendingModifiedValues{})
+}
+
+// statusDBInstanceHasEndpoint returns whether or not a database instance reports its endpoint.
+func statusDBInstanceHasEndpoint(conn *rds.RDS, id string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		output, err := FindDBInstanceByID(conn, id)
+
+		if tfresource.NotFound(err) {
+			return nil, "", nil
+		}
+
+		if err != nil {
+			return nil, "", err
+		}
+
+		return output, strconv.FormatBool(dbInstanceHasEndpoint(output)), nil
+	}
+}
+
+// dbInstanceHasEndpoint returns whether or not a database instance reports its endpoint address.
+func dbInstanceHasEndpoint(dbInstance *rds.DBInstance) bool {
+	return dbInstance != nil && dbInstance.Endpoint != nil && aws.StringValue(dbInstance.Endpoint.Address) != ""
+}
+
+// statusDBInstanceEngineVersionSatisfied returns whether or not a database instance runs the specified engine version.
+func statusDBInstanceEngineVersionSatisfied(conn *rds.RDS, id, engineVersion string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		output, err := FindDBInstanceByID(conn, id)
+
+		if tfresource.NotFound(err) {
+			return nil, "", nil
+		}
+
+		if err != nil {
+			return nil, "", err
+		}
+
+		return output, strconv.FormatBool(engineVersionSatisfies(engineVersion, aws.StringValue(output.EngineVersion))), nil
+	}
+}
+
+// statusDBInstanceCACertificateIdentifierApplied returns whether or not a database instance reports the specified CA certificate.
+func statusDBInstanceCACertificateIdentifierApplied(conn rdsiface.RDSAPI, id, caCertificateIdentifier string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		output, err := FindDBInstanceByID(conn, id)
+
+		if tfresource.NotFound(err) {
+			return nil, "", nil
+		}
+
+		if err != nil {
+			return nil, "", err
+		}
+
+		return output, strconv.FormatBool(aws.StringValue(output.CACertificateIdentifier) == caCertificateIdentifier), nil
+	}
+}
+
+// statusDBInstancePromotionTierApplied returns whether or not a database instance reports the specified promotion tier.
+func statusDBInstancePromotionTierApplied(conn rdsiface.RDSAPI, id string, promotionTier int64) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		output, err := FindDBInstanceByID(conn, id)
+
+		if tfresource.NotFound(err) {
+			return nil, "", nil
+		}
+
+		if err != nil {
+			return nil, "", err
+		}
+
+		return output, strconv.FormatBool(aws.Int64Value(output.PromotionTier) == promotionTier), nil
+	}
+}
+
+// statusDBClusterCloudwatchLogsExportsApplied returns whether or not a database cluster exports exactly the specified
+// log types, with no log exports that are being enabled or disabled.
+func statusDBClusterCloudwatchLogsExportsApplied(conn rdsiface.RDSAPI, id string, logTypes []string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		output, err := FindDBClusterByID(conn, id)
+
+		if tfresource.NotFound(err) {
+			return nil, "", nil
+		}
+
+		if err != nil {
+			return nil, "", err
+		}
+
+		applied := !dbClusterHasPendingCloudwatchLogsExports(output) &&
+			flex.FlattenStringSet(output.EnabledCloudwatchLogsExports).Equal(flex.FlattenStringSet(aws.StringSlice(logTypes)))
+
+		return output, strconv.FormatBool(applied), nil
+	}
+}
+
+func dbClusterHasPendingCloudwatchLogsExports(dbCluster *rds.DBCluster) bool {
+	if dbCluster.PendingModifiedValues == nil || dbCluster.PendingModifiedValues.PendingCloudwatchLogsExports == nil {
+		return false
+	}
+
+	v := dbCluster.PendingModifiedValues.PendingCloudwatchLogsExports
+
+	return len(v.LogTypesToEnable) > 0 || len(v.LogTypesToDisable) > 0
+}
+
+// dbClusterRequestedCloudwatchLogsExports returns the log types that a database cluster exports once its log exports
+// that are being enabled or disabled are applied.
+func dbClusterRequestedCloudwatchLogsExports(dbCluster *rds.DBCluster) []string {
+	logTypes := flex.FlattenStringSet(dbCluster.EnabledCloudwatchLogsExports)
+
+	if dbCluster.PendingModifiedValues != nil && dbCluster.PendingModifiedValues.PendingCloudwatchLogsExports != nil {
+		v := dbCluster.PendingModifiedValues.PendingCloudwatchLogsExports
+
+		logTypes = logTypes.Union(flex.FlattenStringSet(v.LogTypesToEnable)).Difference(flex.FlattenStringSet(v.LogTypesToDisable))
+	}
+
+	return aws.StringValueSlice(flex.ExpandStringSet(logTypes))
+}
+
+// statusDBClusterInstanceIsWriter returns whether or not a database instance is the writer of its database cluster.
+func statusDBClusterInstanceIsWriter(conn *rds.RDS, dbClusterID, dbInstanceID string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		output, err := FindDBClusterByID(conn, dbClusterID)
+
+		if tfresource.NotFound(err) {
+			return nil, "", nil
+		}
+
+		if err != nil {
+			return nil, "", err
+		}
+
+		for _, v := range output.DBClusterMembers {
+			if aws.StringValue(v.DBInstanceIdentifier) == dbInstanceID {
+				return output, strconv.FormatBool(aws.BoolValue(v.IsClusterWriter)), nil
+			}
+		}
+
+		return output, strconv.FormatBool(false), nil
+	}
+}
+
 func statusDBProxy(conn *rds.RDS, name string) resource.StateRefreshFunc {
 	return func() (interface{}, string, error) {
 		output, err := FindDBProxyByName(conn, name)
@@ -0,0 +1,320 @@
+package rds
+
+import (
+	"errors"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/rds"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestDBClusterHasPendingCloudwatchLogsExports(t *testing.T) {
+	cases := []struct {
+		Name      string
+		DBCluster *rds.DBCluster
+		Expected  bool
+	}{
+		{
+			Name:      "no pending modified values",
+			DBCluster: &rds.DBCluster{},
+			Expected:  false,
+		},
+		{
+			Name: "log types to enable",
+			DBCluster: &rds.DBCluster{
+				PendingModifiedValues: &rds.ClusterPendingModifiedValues{
+					PendingCloudwatchLogsExports: &rds.PendingCloudwatchLogsExports{
+						LogTypesToEnable: aws.StringSlice([]string{"audit"}),
+					},
+				},
+			},
+			Expected: true,
+		},
+		{
+			Name: "log types to disable",
+			DBCluster: &rds.DBCluster{
+				PendingModifiedValues: &rds.ClusterPendingModifiedValues{
+					PendingCloudwatchLogsExports: &rds.PendingCloudwatchLogsExports{
+						LogTypesToDisable: aws.StringSlice([]string{"error"}),
+					},
+				},
+			},
+			Expected: true,
+		},
+		{
+			Name: "log exports applied",
+			DBCluster: &rds.DBCluster{
+				EnabledCloudwatchLogsExports: aws.StringSlice([]string{"audit"}),
+				PendingModifiedValues: &rds.ClusterPendingModifiedValues{
+					PendingCloudwatchLogsExports: &rds.PendingCloudwatchLogsExports{},
+				},
+			},
+			Expected: false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			if got := dbClusterHasPendingCloudwatchLogsExports(tc.DBCluster); got != tc.Expected {
+				t.Errorf("got %t, expected %t", got, tc.Expected)
+			}
+		})
+	}
+}
+
+func TestDBClusterRequestedCloudwatchLogsExports(t *testing.T) {
+	cases := []struct {
+		Name      string
+		DBCluster *rds.DBCluster
+		Expected  []string
+	}{
+		{
+			Name:      "no log exports",
+			DBCluster: &rds.DBCluster{},
+			Expected:  []string{},
+		},
+		{
+			Name: "log exports applied",
+			DBCluster: &rds.DBCluster{
+				EnabledCloudwatchLogsExports: aws.StringSlice([]string{"audit", "error"}),
+			},
+			Expected: []string{"audit", "error"},
+		},
+		{
+			Name: "log exports being changed",
+			DBCluster: &rds.DBCluster{
+				EnabledCloudwatchLogsExports: aws.StringSlice([]string{"audit", "error"}),
+				PendingModifiedValues: &rds.ClusterPendingModifiedValues{
+					PendingCloudwatchLogsExports: &rds.PendingCloudwatchLogsExports{
+						LogTypesToDisable: aws.StringSlice([]string{"error"}),
+						LogTypesToEnable:  aws.StringSlice([]string{"general", "slowquery"}),
+					},
+				},
+			},
+			Expected: []string{"audit", "general", "slowquery"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			got := dbClusterRequestedCloudwatchLogsExports(tc.DBCluster)
+			sort.Strings(got)
+
+			if !reflect.DeepEqual(got, tc.Expected) {
+				t.Errorf("got %v, expected %v", got, tc.Expected)
+			}
+		})
+	}
+}
+
+func TestDBInstanceHasPendingModifiedValues(t *testing.T) {
+	cases := []struct {
+		Name       string
+		DBInstance *rds.DBInstance
+		Expected   bool
+	}{
+		{
+			Name:       "no pending modified values",
+			DBInstance: &rds.DBInstance{},
+			Expected:   false,
+		},
+		{
+			Name: "pending instance class",
+			DBInstance: &rds.DBInstance{
+				PendingModifiedValues: &rds.PendingModifiedValues{
+					DBInstanceClass: aws.String("db.r5.large"),
+				},
+			},
+			Expected: true,
+		},
+		{
+			Name: "pending modified values cleared",
+			DBInstance: &rds.DBInstance{
+				PendingModifiedValues: &rds.PendingModifiedValues{},
+			},
+			Expected: false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			if got := dbInstanceHasPendingModifiedValues(tc.DBInstance); got != tc.Expected {
+				t.Errorf("got %t, expected %t", got, tc.Expected)
+			}
+		})
+	}
+}
+
+func TestDBInstanceHasEndpoint(t *testing.T) {
+	cases := []struct {
+		Name       string
+		DBInstance *rds.DBInstance
+		Expected   bool
+	}{
+		{
+			Name:     "no instance",
+			Expected: false,
+		},
+		{
+			Name:       "no endpoint",
+			DBInstance: &rds.DBInstance{},
+			Expected:   false,
+		},
+		{
+			Name: "no endpoint address",
+			DBInstance: &rds.DBInstance{
+				Endpoint: &rds.Endpoint{
+					Port: aws.Int64(3306),
+				},
+			},
+			Expected: false,
+		},
+		{
+			Name: "endpoint address",
+			DBInstance: &rds.DBInstance{
+				Endpoint: &rds.Endpoint{
+					Address: aws.String("test-instance.cluster-abcdefghijkl.us-west-2.rds.amazonaws.com"),
+					Port:    aws.Int64(3306),
+				},
+			},
+			Expected: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			if got := dbInstanceHasEndpoint(tc.DBInstance); got != tc.Expected {
+				t.Errorf("got %t, expected %t", got, tc.Expected)
+			}
+		})
+	}
+}
+
+func TestStatusDBInstanceRecordState(t *testing.T) {
+	var calls int
+	statuses := []string{InstanceStatusModifying, InstanceStatusConfiguringLogExports, InstanceStatusAvailable}
+	refresh := func() (interface{}, string, error) {
+		status := statuses[calls]
+		calls++
+
+		return &rds.DBInstance{DBInstanceStatus: aws.String(status)}, status, nil
+	}
+
+	var entered bool
+	f := statusDBInstanceRecordState(refresh, InstanceStatusConfiguringLogExports, &entered)
+
+	for i, expected := range []bool{false, true, true} {
+		if _, _, err := f(); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if entered != expected {
+			t.Errorf("call %d: got entered %t, expected %t", i+1, entered, expected)
+		}
+	}
+}
+
+func TestStatusDBInstanceFailOnStates(t *testing.T) {
+	refreshErr := errors.New("test")
+
+	cases := []struct {
+		Name          string
+		Refresh       resource.StateRefreshFunc
+		ExpectedState string
+		ExpectedError string
+	}{
+		{
+			Name: "deleting",
+			Refresh: func() (interface{}, string, error) {
+				return &rds.DBInstance{DBInstanceStatus: aws.String(InstanceStatusDeleting)}, InstanceStatusDeleting, nil
+			},
+			ExpectedState: InstanceStatusDeleting,
+		},
+		{
+			Name: "not found",
+			Refresh: func() (interface{}, string, error) {
+				return nil, "", nil
+			},
+		},
+		{
+			Name: "refresh error",
+			Refresh: func() (interface{}, string, error) {
+				return nil, "", refreshErr
+			},
+			ExpectedError: "test",
+		},
+		{
+			Name: "incompatible-network",
+			Refresh: func() (interface{}, string, error) {
+				return &rds.DBInstance{DBInstanceStatus: aws.String(InstanceStatusIncompatibleNetwork)}, InstanceStatusIncompatibleNetwork, nil
+			},
+			ExpectedState: InstanceStatusIncompatibleNetwork,
+			ExpectedError: `DB instance entered state "incompatible-network"`,
+		},
+		{
+			Name: "incompatible-network with status info",
+			Refresh: func() (interface{}, string, error) {
+				return &rds.DBInstance{
+					DBInstanceStatus: aws.String(InstanceStatusIncompatibleNetwork),
+					StatusInfos: []*rds.DBInstanceStatusInfo{
+						{Message: aws.String("The subnet has no free IP addresses")},
+					},
+				}, InstanceStatusIncompatibleNetwork, nil
+			},
+			ExpectedState: InstanceStatusIncompatibleNetwork,
+			ExpectedError: `DB instance entered state "incompatible-network": The subnet has no free IP addresses`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			_, state, err := statusDBInstanceFailOnStates(tc.Refresh, InstanceStatusFailed, InstanceStatusIncompatibleNetwork)()
+
+			if state != tc.ExpectedState {
+				t.Errorf("got state %q, expected %q", state, tc.ExpectedState)
+			}
+
+			if tc.ExpectedError == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				return
+			}
+
+			if err == nil {
+				t.Fatalf("expected error %q, got none", tc.ExpectedError)
+			}
+
+			if err.Error() != tc.ExpectedError {
+				t.Errorf("got error %q, expected %q", err, tc.ExpectedError)
+			}
+		})
+	}
+}
+
+func TestStatusDBInstanceFailOnStates_WaitForState(t *testing.T) {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{InstanceStatusDeleting},
+		Target:  []string{},
+		Refresh: statusDBInstanceFailOnStates(func() (interface{}, string, error) {
+			return &rds.DBInstance{DBInstanceStatus: aws.String(InstanceStatusIncompatibleNetwork)}, InstanceStatusIncompatibleNetwork, nil
+		}, InstanceStatusIncompatibleNetwork),
+		Timeout: 1 * time.Hour,
+	}
+
+	start := time.Now()
+	_, err := stateConf.WaitForState()
+
+	if err == nil {
+		t.Fatal("expected error, got none")
+	}
+
+	if elapsed := time.Since(start); elapsed > 1*time.Minute {
+		t.Errorf("got elapsed %s, expected the waiter to fail immediately", elapsed)
+	}
+}
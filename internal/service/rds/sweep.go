@@ -379,16 +379,7 @@ func sweepInstances(region string) error {
 	conn := client.(*conns.AWSClient).RDSConn
 	sweepResources := make([]*sweep.SweepResource, 0)
 
-	err = conn.DescribeDBInstancesPages(&rds.DescribeDBInstancesInput{}, func(out *rds.DescribeDBInstancesOutput, lastPage bool) bool {
-		for _, dbi := range out.DBInstances {
-			r := ResourceInstance()
-			d := r.Data(nil)
-			d.SetId(aws.StringValue(dbi.DBInstanceIdentifier))
-			d.Set("skip_final_snapshot", true)
-			sweepResources = append(sweepResources, sweep.NewSweepResource(r, d, client))
-		}
-		return !lastPage
-	})
+	dbInstances, err := FindDBInstances(conn, &rds.DescribeDBInstancesInput{})
 	if err != nil {
 		if sweep.SkipSweepError(err) {
 			log.Printf("[WARN] Skipping RDS DB Instance sweep for %s: %s", region, err)
@@ -397,6 +388,14 @@ func sweepInstances(region string) error {
 		return fmt.Errorf("Error retrieving DB instances: %s", err)
 	}
 
+	for _, dbi := range dbInstances {
+		r := ResourceInstance()
+		d := r.Data(nil)
+		d.SetId(aws.StringValue(dbi.DBInstanceIdentifier))
+		d.Set("skip_final_snapshot", true)
+		sweepResources = append(sweepResources, sweep.NewSweepResource(r, d, client))
+	}
+
 	return sweep.SweepOrchestrator(sweepResources)
 }
 
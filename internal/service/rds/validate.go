@@ -3,9 +3,16 @@ package rds
 import (
 	"fmt"
 	"regexp"
+	"sort"
+	"strings"
+	"time"
 
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/service/rds"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	tftags "github.com/hashicorp/terraform-provider-aws/internal/tags"
 )
 
 func validEventSubscriptionName(v interface{}, k string) (ws []string, errors []error) {
@@ -147,6 +154,31 @@ func validEngine() schema.SchemaValidateFunc {
 	return validation.StringInSlice(Engine_Values(), false)
 }
 
+// validClusterInstanceEngine validates `engine`, warning when it is the deprecated "aurora" (Aurora MySQL 5.6) engine.
+func validClusterInstanceEngine(v interface{}, k string) (ws []string, errors []error) {
+	ws, errors = validEngine()(v, k)
+
+	if v.(string) == EngineAurora {
+		ws = append(ws, deprecatedEngineAuroraMessage(k))
+	}
+
+	return
+}
+
+func deprecatedEngineAuroraMessage(k string) string {
+	return fmt.Sprintf("%s %q (Aurora MySQL 5.6) is deprecated and no longer available for new DB instances in many Regions, use %q instead", k, EngineAurora, EngineAuroraMySQL)
+}
+
+// isAuroraEngine returns whether the specified engine is an Amazon Aurora engine.
+func isAuroraEngine(engine string) bool {
+	switch engine {
+	case EngineAurora, EngineAuroraMySQL, EngineAuroraPostgreSQL:
+		return true
+	}
+
+	return false
+}
+
 func validIdentifier(v interface{}, k string) (ws []string, errors []error) {
 	value := v.(string)
 	if !regexp.MustCompile(`^[0-9a-z-]+$`).MatchString(value) {
@@ -184,3 +216,372 @@ func validIdentifierPrefix(v interface{}, k string) (ws []string, errors []error
 	}
 	return
 }
+
+// validateClusterInstanceBackupTarget validates that `backup_target` is supported by `engine`.
+// Automated backups can only be kept on Outposts for non-Aurora engines.
+func validateClusterInstanceBackupTarget(engine, backupTarget string) error {
+	if backupTarget != BackupTargetOutposts {
+		return nil
+	}
+
+	if isAuroraEngine(engine) {
+		return fmt.Errorf("backup_target %q is not supported for engine %q", backupTarget, engine)
+	}
+
+	return nil
+}
+
+// validateClusterInstancePort validates that `port` can be configured for `engine`.
+// Aurora instances always use the port of their cluster.
+func validateClusterInstancePort(engine string) error {
+	if isAuroraEngine(engine) {
+		return fmt.Errorf("port cannot be configured for engine %q, Aurora DB instances use the port of the DB cluster", engine)
+	}
+
+	return nil
+}
+
+// validateClusterInstanceFinalSnapshot validates the `skip_final_snapshot` and `final_snapshot_identifier` combination for `engine`.
+// Final snapshots of Aurora DB instances are taken at the cluster level.
+func validateClusterInstanceFinalSnapshot(engine string, skipFinalSnapshot bool, finalSnapshotID string) error {
+	if skipFinalSnapshot {
+		return nil
+	}
+
+	if isAuroraEngine(engine) {
+		return fmt.Errorf("skip_final_snapshot cannot be false for engine %q, Aurora final snapshots are configured on the DB cluster", engine)
+	}
+
+	if finalSnapshotID == "" {
+		return fmt.Errorf("final_snapshot_identifier is required when skip_final_snapshot is false")
+	}
+
+	return nil
+}
+
+// validateClusterInstanceParameterGroupFamily validates that a DB parameter group family
+// (e.g. "aurora-mysql8.0" or "aurora-postgresql13") is compatible with `engine` and,
+// if known, `engine_version`.
+func validateClusterInstanceParameterGroupFamily(engine, engineVersion, family string) error {
+	familyVersion := strings.TrimPrefix(family, engine)
+
+	// The remainder must be the family version, not another engine's suffix, e.g. "aurora" vs. "aurora-mysql5.7".
+	if familyVersion == family || familyVersion == "" || familyVersion[0] < '0' || familyVersion[0] > '9' {
+		return fmt.Errorf("DB parameter group family %q is not compatible with engine %q", family, engine)
+	}
+
+	if engineVersion != "" && engineVersion != familyVersion && !strings.HasPrefix(engineVersion, familyVersion+".") {
+		return fmt.Errorf("DB parameter group family %q is not compatible with engine %q version %q", family, engine, engineVersion)
+	}
+
+	return nil
+}
+
+// validateClusterInstancePubliclyAccessible validates setting `publicly_accessible` to true against `allow_public_access`.
+// When allow_public_access isn't set (nil) a warning is returned, when it's false an error and when it's true neither.
+func validateClusterInstancePubliclyAccessible(allowPublicAccess *bool) ([]string, error) {
+	const message = "publicly_accessible is true, which makes the DB instance reachable from the internet wherever its VPC security groups allow"
+
+	if allowPublicAccess == nil {
+		return []string{message + ", set allow_public_access to true to confirm or to false to block this"}, nil
+	}
+
+	if !aws.BoolValue(allowPublicAccess) {
+		return nil, fmt.Errorf("%s. Set publicly_accessible to false, or set allow_public_access to true to confirm", message)
+	}
+
+	return nil, nil
+}
+
+// validateClusterInstanceMonitoringInterval returns a warning when a change of `monitoring_interval` is deferred to the
+// next maintenance window, during which time the current Enhanced Monitoring configuration remains in effect.
+func validateClusterInstanceMonitoringInterval(oldInterval, newInterval int, applyImmediately bool) []string {
+	if oldInterval == newInterval || applyImmediately {
+		return nil
+	}
+
+	message := fmt.Sprintf("monitoring_interval changes from %d to %d during the next maintenance window, as it isn't applied immediately", oldInterval, newInterval)
+
+	if oldInterval == 0 {
+		message += ". No Enhanced Monitoring metrics are collected until then"
+	} else {
+		message += ". Enhanced Monitoring metrics are collected at the current interval until then"
+	}
+
+	return []string{message + ", set apply_immediately to true or override monitoring_interval in apply_immediately_overrides to apply it now"}
+}
+
+// validateClusterInstancePerformanceInsightsRetentionPeriod validates that a `performance_insights_retention_period`
+// is only set when Performance Insights is, or becomes, enabled.
+func validateClusterInstancePerformanceInsightsRetentionPeriod(performanceInsightsEnabled bool, retentionPeriod int) error {
+	if performanceInsightsEnabled || retentionPeriod == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("performance_insights_retention_period (%d) can only be set when performance_insights_enabled is true", retentionPeriod)
+}
+
+// validateClusterInstancePerformanceInsightsKMSKeyID validates that the `performance_insights_kms_key_id` ARN is in `region`.
+// RDS can't use a KMS key in another Region for Performance Insights.
+func validateClusterInstancePerformanceInsightsKMSKeyID(kmsKeyID, region string) error {
+	keyARN, err := arn.Parse(kmsKeyID)
+
+	if err != nil {
+		return fmt.Errorf("performance_insights_kms_key_id (%s) is not a valid ARN: %w", kmsKeyID, err)
+	}
+
+	if keyARN.Region != region {
+		return fmt.Errorf("performance_insights_kms_key_id (%s) must be in the same Region as the DB instance (%s)", kmsKeyID, region)
+	}
+
+	return nil
+}
+
+// validateTags validates that `tags` are within the RDS tag limits.
+func validateTags(tags tftags.KeyValueTags) error {
+	tags = tags.IgnoreAWS()
+
+	if n := len(tags); n > maxTagsPerResource {
+		return fmt.Errorf("too many tags (%d), a resource can have at most %d tags", n, maxTagsPerResource)
+	}
+
+	for k, v := range tags.Map() {
+		if n := len(k); n > maxTagKeyLength {
+			return fmt.Errorf("tag key %q is too long (%d), tag keys can be at most %d characters", k, n, maxTagKeyLength)
+		}
+
+		if n := len(v); n > maxTagValueLength {
+			return fmt.Errorf("value of tag %q is too long (%d), tag values can be at most %d characters", k, n, maxTagValueLength)
+		}
+	}
+
+	return nil
+}
+
+// clusterInstanceApplyImmediatelyAttributes are the attributes whose modifications can be applied immediately
+// or during the next maintenance window.
+var clusterInstanceApplyImmediatelyAttributes = []string{
+	"auto_minor_version_upgrade",
+	"ca_cert_identifier",
+	"copy_tags_to_snapshot",
+	"db_parameter_group_name",
+	"instance_class",
+	"monitoring_interval",
+	"monitoring_role_arn",
+	"performance_insights_enabled",
+	"performance_insights_kms_key_id",
+	"performance_insights_retention_period",
+	"port",
+	"preferred_backup_window",
+	"preferred_maintenance_window",
+	"promotion_tier",
+	"publicly_accessible",
+}
+
+// validateClusterInstanceApplyImmediatelyOverrides validates that the keys of `apply_immediately_overrides`
+// are attributes whose modifications can be applied immediately or during the next maintenance window.
+func validateClusterInstanceApplyImmediatelyOverrides(v interface{}, k string) (ws []string, errors []error) {
+	for key := range v.(map[string]interface{}) {
+		valid := false
+
+		for _, attribute := range clusterInstanceApplyImmediatelyAttributes {
+			if key == attribute {
+				valid = true
+				break
+			}
+		}
+
+		if !valid {
+			errors = append(errors, fmt.Errorf("%s: %q is not an attribute whose modifications can be deferred, valid attributes: %s", k, key, strings.Join(clusterInstanceApplyImmediatelyAttributes, ", ")))
+		}
+	}
+
+	return
+}
+
+// clusterInstanceApplyImmediately returns whether modifications of the specified changed attributes, which are
+// requested together, are applied immediately. An attribute's override in `apply_immediately_overrides` takes
+// precedence over `apply_immediately`. If any of the attributes is applied immediately, they all are.
+func clusterInstanceApplyImmediately(applyImmediately bool, overrides map[string]interface{}, keys ...string) bool {
+	if len(keys) == 0 {
+		return applyImmediately
+	}
+
+	for _, key := range keys {
+		if v, ok := overrides[key]; ok {
+			if v.(bool) {
+				return true
+			}
+
+			continue
+		}
+
+		if applyImmediately {
+			return true
+		}
+	}
+
+	return false
+}
+
+// clusterInstanceEngineLicenseModels returns the license models supported by the specified engine, or nil if they aren't known.
+func clusterInstanceEngineLicenseModels(engine string) []string {
+	switch {
+	case engine == EngineAurora, engine == EngineAuroraMySQL, engine == EngineMySQL:
+		return []string{licenseModelGeneralPublicLicense}
+	case engine == EngineAuroraPostgreSQL, engine == EnginePostgres:
+		return []string{licenseModelPostgreSQLLicense}
+	}
+
+	return nil
+}
+
+// validateClusterInstanceLicenseModel validates `license_model` against the license models supported by `engine`, where known.
+func validateClusterInstanceLicenseModel(engine, licenseModel string) error {
+	licenseModels := clusterInstanceEngineLicenseModels(engine)
+
+	if licenseModels == nil {
+		return nil
+	}
+
+	for _, v := range licenseModels {
+		if v == licenseModel {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("license_model %q is not supported by engine %q, supported license models: %s", licenseModel, engine, strings.Join(licenseModels, ", "))
+}
+
+// validateClusterInstanceServerlessInstanceClass validates a change of `instance_class` to or from "db.serverless".
+// Only DB clusters configured for Aurora Serverless v2 can have db.serverless DB instances.
+func validateClusterInstanceServerlessInstanceClass(oldInstanceClass, newInstanceClass string, dbCluster *rds.DBCluster) error {
+	if (oldInstanceClass == instanceClassServerless) == (newInstanceClass == instanceClassServerless) {
+		return nil
+	}
+
+	if dbCluster.ServerlessV2ScalingConfiguration != nil {
+		return nil
+	}
+
+	clusterID := aws.StringValue(dbCluster.DBClusterIdentifier)
+
+	if oldInstanceClass == "" {
+		return fmt.Errorf("instance_class %q requires RDS Cluster (%s) to be configured for Aurora Serverless v2 (serverlessv2_scaling_configuration)", newInstanceClass, clusterID)
+	}
+
+	return fmt.Errorf("changing instance_class from %q to %q requires RDS Cluster (%s) to be configured for Aurora Serverless v2 (serverlessv2_scaling_configuration)", oldInstanceClass, newInstanceClass, clusterID)
+}
+
+// validateClusterInstanceClusterEngineMode validates that instances can be added to the DB cluster.
+// Aurora Serverless v1 DB clusters scale capacity without DB instances.
+func validateClusterInstanceClusterEngineMode(dbCluster *rds.DBCluster) error {
+	if aws.StringValue(dbCluster.EngineMode) != EngineModeServerless {
+		return nil
+	}
+
+	return fmt.Errorf("RDS Cluster (%s) is an Aurora Serverless v1 cluster (engine_mode %q), which doesn't support cluster instances. Use an Aurora Serverless v2 cluster (engine_mode %q with serverlessv2_scaling_configuration) and instance_class %q instead", aws.StringValue(dbCluster.DBClusterIdentifier), EngineModeServerless, EngineModeProvisioned, instanceClassServerless)
+}
+
+// validateClusterInstanceAvailabilityZone validates that `availability_zone` is covered by a subnet of the DB subnet group.
+func validateClusterInstanceAvailabilityZone(availabilityZone string, dbSubnetGroup *rds.DBSubnetGroup) error {
+	var availabilityZones []string
+
+	for _, v := range dbSubnetGroup.Subnets {
+		if v == nil || v.SubnetAvailabilityZone == nil {
+			continue
+		}
+
+		name := aws.StringValue(v.SubnetAvailabilityZone.Name)
+
+		if name == availabilityZone {
+			return nil
+		}
+
+		availabilityZones = append(availabilityZones, name)
+	}
+
+	sort.Strings(availabilityZones)
+
+	return fmt.Errorf("availability_zone %q isn't covered by RDS DB Subnet Group (%s), which has subnets in: %s", availabilityZone, aws.StringValue(dbSubnetGroup.DBSubnetGroupName), strings.Join(availabilityZones, ", "))
+}
+
+// validateClusterInstanceOrderableInstanceClass validates that `instance_class` is one of the orderable DB instance options
+// of the engine (and engine version) in the Region.
+func validateClusterInstanceOrderableInstanceClass(instanceClass, engine, engineVersion string, options []*rds.OrderableDBInstanceOption) error {
+	var instanceClasses []string
+	seen := make(map[string]struct{})
+
+	for _, option := range options {
+		v := aws.StringValue(option.DBInstanceClass)
+
+		if v == instanceClass {
+			return nil
+		}
+
+		if _, ok := seen[v]; !ok {
+			seen[v] = struct{}{}
+			instanceClasses = append(instanceClasses, v)
+		}
+	}
+
+	sort.Strings(instanceClasses)
+
+	if engineVersion == "" {
+		return fmt.Errorf("instance_class %q is not available for engine %q in this Region, available instance classes: %s", instanceClass, engine, strings.Join(instanceClasses, ", "))
+	}
+
+	return fmt.Errorf("instance_class %q is not available for engine %q version %q in this Region, available instance classes: %s", instanceClass, engine, engineVersion, strings.Join(instanceClasses, ", "))
+}
+
+// validateCertificateIdentifier validates that `ca_cert_identifier` is one of the CA certificates available in the Region.
+func validateCertificateIdentifier(id string, certificates []*rds.Certificate) error {
+	var ids []string
+
+	for _, certificate := range certificates {
+		v := aws.StringValue(certificate.CertificateIdentifier)
+
+		if v == id {
+			return nil
+		}
+
+		ids = append(ids, v)
+	}
+
+	return fmt.Errorf("ca_cert_identifier %q is not available in this Region, available CA certificates: %s", id, strings.Join(ids, ", "))
+}
+
+// clusterInstanceUpdateAppliesImmediately returns whether an update makes changes right away rather than during the
+// maintenance window, so that `change_freeze` applies to it. maintenanceActionOptInType is the opt-in type with which
+// pending maintenance actions are applied, or "" if `apply_maintenance_action` didn't change.
+func clusterInstanceUpdateAppliesImmediately(requestImmediate, enableAutoMinorVersionUpgrade, rebootTriggerChanged bool, maintenanceActionOptInType string) bool {
+	return requestImmediate || enableAutoMinorVersionUpgrade || rebootTriggerChanged || maintenanceActionOptInType == OptInTypeImmediate
+}
+
+// validateClusterInstanceChangeFreeze validates that the specified time isn't within the `change_freeze` window.
+// The window includes its start and excludes its end.
+func validateClusterInstanceChangeFreeze(tfList []interface{}, now time.Time) error {
+	if len(tfList) == 0 || tfList[0] == nil {
+		return nil
+	}
+
+	tfMap := tfList[0].(map[string]interface{})
+
+	start, err := time.Parse(time.RFC3339, tfMap["start"].(string))
+
+	if err != nil {
+		return fmt.Errorf("parsing change_freeze start: %w", err)
+	}
+
+	end, err := time.Parse(time.RFC3339, tfMap["end"].(string))
+
+	if err != nil {
+		return fmt.Errorf("parsing change_freeze end: %w", err)
+	}
+
+	if now.Before(start) || !now.Before(end) {
+		return nil
+	}
+
+	return fmt.Errorf("changes are frozen from %s until %s, only modifications deferred to the maintenance window (apply_immediately false) are allowed", start.Format(time.RFC3339), end.Format(time.RFC3339))
+}
@@ -1,10 +1,15 @@
 package rds
 
 import (
+	"fmt"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/rds"
 	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	tftags "github.com/hashicorp/terraform-provider-aws/internal/tags"
 )
 
 func TestValidEventSubscriptionName(t *testing.T) {
@@ -211,3 +216,886 @@ func TestValidSubnetGroupNamePrefix(t *testing.T) {
 		}
 	}
 }
+
+func TestValidClusterInstanceEngine(t *testing.T) {
+	cases := []struct {
+		Value     string
+		WarnCount int
+		ErrCount  int
+	}{
+		{
+			Value:     EngineAurora,
+			WarnCount: 1,
+			ErrCount:  0,
+		},
+		{
+			Value:     EngineAuroraMySQL,
+			WarnCount: 0,
+			ErrCount:  0,
+		},
+		{
+			Value:     EngineAuroraPostgreSQL,
+			WarnCount: 0,
+			ErrCount:  0,
+		},
+		{
+			Value:     "oracle-ee",
+			WarnCount: 0,
+			ErrCount:  1,
+		},
+	}
+
+	for _, tc := range cases {
+		ws, errors := validClusterInstanceEngine(tc.Value, "engine")
+
+		if len(ws) != tc.WarnCount {
+			t.Fatalf("expected %d warnings for engine %q, got: %v", tc.WarnCount, tc.Value, ws)
+		}
+
+		if len(errors) != tc.ErrCount {
+			t.Fatalf("expected %d errors for engine %q, got: %v", tc.ErrCount, tc.Value, errors)
+		}
+	}
+}
+
+func TestValidateClusterInstanceBackupTarget(t *testing.T) {
+	cases := []struct {
+		Engine       string
+		BackupTarget string
+		ErrCount     int
+	}{
+		{
+			Engine:       EngineMySQL,
+			BackupTarget: BackupTargetOutposts,
+			ErrCount:     0,
+		},
+		{
+			Engine:       EnginePostgres,
+			BackupTarget: BackupTargetOutposts,
+			ErrCount:     0,
+		},
+		{
+			Engine:       EngineAurora,
+			BackupTarget: BackupTargetOutposts,
+			ErrCount:     1,
+		},
+		{
+			Engine:       EngineAuroraMySQL,
+			BackupTarget: BackupTargetOutposts,
+			ErrCount:     1,
+		},
+		{
+			Engine:       EngineAuroraPostgreSQL,
+			BackupTarget: BackupTargetOutposts,
+			ErrCount:     1,
+		},
+		{
+			Engine:       EngineAuroraPostgreSQL,
+			BackupTarget: BackupTargetRegion,
+			ErrCount:     0,
+		},
+	}
+
+	for _, tc := range cases {
+		err := validateClusterInstanceBackupTarget(tc.Engine, tc.BackupTarget)
+		if tc.ErrCount == 0 && err != nil {
+			t.Fatalf("expected engine %q with backup_target %q to be valid, got: %s", tc.Engine, tc.BackupTarget, err)
+		}
+		if tc.ErrCount != 0 && err == nil {
+			t.Fatalf("expected engine %q with backup_target %q to be invalid", tc.Engine, tc.BackupTarget)
+		}
+	}
+}
+
+func TestValidateClusterInstanceParameterGroupFamily(t *testing.T) {
+	cases := []struct {
+		Engine        string
+		EngineVersion string
+		Family        string
+		ErrCount      int
+	}{
+		{
+			Engine:   EngineAurora,
+			Family:   "aurora5.6",
+			ErrCount: 0,
+		},
+		{
+			Engine:        EngineAurora,
+			EngineVersion: "5.6.mysql_aurora.1.22.2",
+			Family:        "aurora5.6",
+			ErrCount:      0,
+		},
+		{
+			Engine:   EngineAurora,
+			Family:   "aurora-mysql5.7",
+			ErrCount: 1,
+		},
+		{
+			Engine:        EngineAuroraMySQL,
+			EngineVersion: "8.0.mysql_aurora.3.02.0",
+			Family:        "aurora-mysql8.0",
+			ErrCount:      0,
+		},
+		{
+			Engine:        EngineAuroraMySQL,
+			EngineVersion: "5.7.mysql_aurora.2.10.2",
+			Family:        "aurora-mysql8.0",
+			ErrCount:      1,
+		},
+		{
+			Engine:        EngineAuroraPostgreSQL,
+			EngineVersion: "13.7",
+			Family:        "aurora-postgresql13",
+			ErrCount:      0,
+		},
+		{
+			Engine:        EngineAuroraPostgreSQL,
+			EngineVersion: "9.6.22",
+			Family:        "aurora-postgresql9.6",
+			ErrCount:      0,
+		},
+		{
+			Engine:        EngineAuroraPostgreSQL,
+			EngineVersion: "13.7",
+			Family:        "aurora-postgresql1",
+			ErrCount:      1,
+		},
+		{
+			Engine:   EngineAuroraPostgreSQL,
+			Family:   "aurora-mysql8.0",
+			ErrCount: 1,
+		},
+		{
+			Engine:        EnginePostgres,
+			EngineVersion: "13.4",
+			Family:        "postgres13",
+			ErrCount:      0,
+		},
+		{
+			Engine:   EnginePostgres,
+			Family:   "aurora-postgresql13",
+			ErrCount: 1,
+		},
+	}
+
+	for _, tc := range cases {
+		err := validateClusterInstanceParameterGroupFamily(tc.Engine, tc.EngineVersion, tc.Family)
+		if tc.ErrCount == 0 && err != nil {
+			t.Fatalf("expected family %q to be valid for engine %q version %q, got: %s", tc.Family, tc.Engine, tc.EngineVersion, err)
+		}
+		if tc.ErrCount != 0 && err == nil {
+			t.Fatalf("expected family %q to be invalid for engine %q version %q", tc.Family, tc.Engine, tc.EngineVersion)
+		}
+	}
+}
+
+func TestValidateClusterInstancePort(t *testing.T) {
+	validEngines := []string{
+		EngineMySQL,
+		EnginePostgres,
+	}
+	for _, v := range validEngines {
+		if err := validateClusterInstancePort(v); err != nil {
+			t.Fatalf("expected port to be configurable for engine %q, got: %s", v, err)
+		}
+	}
+
+	invalidEngines := []string{
+		EngineAurora,
+		EngineAuroraMySQL,
+		EngineAuroraPostgreSQL,
+	}
+	for _, v := range invalidEngines {
+		if err := validateClusterInstancePort(v); err == nil {
+			t.Fatalf("expected port not to be configurable for engine %q", v)
+		}
+	}
+}
+
+func TestValidateClusterInstanceFinalSnapshot(t *testing.T) {
+	cases := []struct {
+		Engine            string
+		SkipFinalSnapshot bool
+		FinalSnapshotID   string
+		ErrCount          int
+	}{
+		{
+			Engine:            EngineMySQL,
+			SkipFinalSnapshot: true,
+			ErrCount:          0,
+		},
+		{
+			Engine:            EngineMySQL,
+			SkipFinalSnapshot: false,
+			FinalSnapshotID:   "final-snapshot",
+			ErrCount:          0,
+		},
+		{
+			Engine:            EnginePostgres,
+			SkipFinalSnapshot: false,
+			ErrCount:          1,
+		},
+		{
+			Engine:            EngineAuroraMySQL,
+			SkipFinalSnapshot: true,
+			ErrCount:          0,
+		},
+		{
+			Engine:            EngineAuroraPostgreSQL,
+			SkipFinalSnapshot: false,
+			FinalSnapshotID:   "final-snapshot",
+			ErrCount:          1,
+		},
+	}
+
+	for _, tc := range cases {
+		err := validateClusterInstanceFinalSnapshot(tc.Engine, tc.SkipFinalSnapshot, tc.FinalSnapshotID)
+		if tc.ErrCount == 0 && err != nil {
+			t.Fatalf("expected engine %q with skip_final_snapshot %t and final_snapshot_identifier %q to be valid, got: %s", tc.Engine, tc.SkipFinalSnapshot, tc.FinalSnapshotID, err)
+		}
+		if tc.ErrCount != 0 && err == nil {
+			t.Fatalf("expected engine %q with skip_final_snapshot %t and final_snapshot_identifier %q to be invalid", tc.Engine, tc.SkipFinalSnapshot, tc.FinalSnapshotID)
+		}
+	}
+}
+
+func TestValidateClusterInstancePerformanceInsightsKMSKeyID(t *testing.T) {
+	cases := []struct {
+		KMSKeyID string
+		Region   string
+		ErrCount int
+	}{
+		{
+			KMSKeyID: "arn:aws:kms:us-west-2:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab", //lintignore:AWSAT003,AWSAT005
+			Region:   "us-west-2",                                                                   //lintignore:AWSAT003
+			ErrCount: 0,
+		},
+		{
+			KMSKeyID: "arn:aws:kms:us-east-1:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab", //lintignore:AWSAT003,AWSAT005
+			Region:   "us-west-2",                                                                   //lintignore:AWSAT003
+			ErrCount: 1,
+		},
+		{
+			KMSKeyID: "1234abcd-12ab-34cd-56ef-1234567890ab",
+			Region:   "us-west-2", //lintignore:AWSAT003
+			ErrCount: 1,
+		},
+	}
+
+	for _, tc := range cases {
+		err := validateClusterInstancePerformanceInsightsKMSKeyID(tc.KMSKeyID, tc.Region)
+		if tc.ErrCount == 0 && err != nil {
+			t.Fatalf("expected performance_insights_kms_key_id %q to be valid in Region %q, got: %s", tc.KMSKeyID, tc.Region, err)
+		}
+		if tc.ErrCount != 0 && err == nil {
+			t.Fatalf("expected performance_insights_kms_key_id %q to be invalid in Region %q", tc.KMSKeyID, tc.Region)
+		}
+	}
+}
+
+func TestValidateTags(t *testing.T) {
+	tags := func(n int) map[string]interface{} {
+		m := make(map[string]interface{}, n)
+		for i := 0; i < n; i++ {
+			m[fmt.Sprintf("key%d", i)] = "value"
+		}
+		return m
+	}
+
+	cases := []struct {
+		Name     string
+		Tags     map[string]interface{}
+		ErrCount int
+	}{
+		{
+			Name:     "no tags",
+			Tags:     nil,
+			ErrCount: 0,
+		},
+		{
+			Name:     "50 tags",
+			Tags:     tags(50),
+			ErrCount: 0,
+		},
+		{
+			Name:     "51 tags",
+			Tags:     tags(51),
+			ErrCount: 1,
+		},
+		{
+			Name: "50 tags and an AWS tag",
+			Tags: func() map[string]interface{} {
+				m := tags(50)
+				m["aws:cloudformation:stack-name"] = "test"
+				return m
+			}(),
+			ErrCount: 0,
+		},
+		{
+			Name:     "128 character key",
+			Tags:     map[string]interface{}{strings.Repeat("k", 128): "value"},
+			ErrCount: 0,
+		},
+		{
+			Name:     "129 character key",
+			Tags:     map[string]interface{}{strings.Repeat("k", 129): "value"},
+			ErrCount: 1,
+		},
+		{
+			Name:     "256 character value",
+			Tags:     map[string]interface{}{"key": strings.Repeat("v", 256)},
+			ErrCount: 0,
+		},
+		{
+			Name:     "257 character value",
+			Tags:     map[string]interface{}{"key": strings.Repeat("v", 257)},
+			ErrCount: 1,
+		},
+	}
+
+	for _, tc := range cases {
+		err := validateTags(tftags.New(tc.Tags))
+		if tc.ErrCount == 0 && err != nil {
+			t.Fatalf("expected %s to be valid, got: %s", tc.Name, err)
+		}
+		if tc.ErrCount != 0 && err == nil {
+			t.Fatalf("expected %s to be invalid", tc.Name)
+		}
+	}
+}
+
+func TestValidateCertificateIdentifier(t *testing.T) {
+	certificates := []*rds.Certificate{
+		{CertificateIdentifier: aws.String("rds-ca-rsa2048-g1")},
+		{CertificateIdentifier: aws.String("rds-ca-rsa4096-g1")},
+		{CertificateIdentifier: aws.String("rds-ca-ecc384-g1")},
+	}
+
+	if err := validateCertificateIdentifier("rds-ca-rsa2048-g1", certificates); err != nil {
+		t.Fatalf("expected rds-ca-rsa2048-g1 to be valid, got: %s", err)
+	}
+
+	err := validateCertificateIdentifier("rds-ca-2019", certificates)
+	if err == nil {
+		t.Fatal("expected rds-ca-2019 to be invalid")
+	}
+	if !strings.Contains(err.Error(), "rds-ca-rsa2048-g1, rds-ca-rsa4096-g1, rds-ca-ecc384-g1") {
+		t.Fatalf("expected error to list available CA certificates, got: %s", err)
+	}
+
+	if err := validateCertificateIdentifier("rds-ca-2019", nil); err == nil {
+		t.Fatal("expected rds-ca-2019 to be invalid with no available CA certificates")
+	}
+}
+
+func TestValidateClusterInstanceServerlessInstanceClass(t *testing.T) {
+	serverlessV2Cluster := &rds.DBCluster{
+		DBClusterIdentifier: aws.String("test-cluster"),
+		ServerlessV2ScalingConfiguration: &rds.ServerlessV2ScalingConfigurationInfo{
+			MaxCapacity: aws.Float64(2),
+			MinCapacity: aws.Float64(0.5),
+		},
+	}
+	provisionedCluster := &rds.DBCluster{
+		DBClusterIdentifier: aws.String("test-cluster"),
+	}
+
+	cases := []struct {
+		Name             string
+		OldInstanceClass string
+		NewInstanceClass string
+		DBCluster        *rds.DBCluster
+		ExpectError      bool
+	}{
+		{
+			Name:             "provisioned to provisioned",
+			OldInstanceClass: "db.r5.large",
+			NewInstanceClass: "db.r5.xlarge",
+			DBCluster:        provisionedCluster,
+		},
+		{
+			Name:             "create serverless in serverless v2 cluster",
+			NewInstanceClass: "db.serverless",
+			DBCluster:        serverlessV2Cluster,
+		},
+		{
+			Name:             "create serverless in provisioned cluster",
+			NewInstanceClass: "db.serverless",
+			DBCluster:        provisionedCluster,
+			ExpectError:      true,
+		},
+		{
+			Name:             "provisioned to serverless in serverless v2 cluster",
+			OldInstanceClass: "db.r5.large",
+			NewInstanceClass: "db.serverless",
+			DBCluster:        serverlessV2Cluster,
+		},
+		{
+			Name:             "provisioned to serverless in provisioned cluster",
+			OldInstanceClass: "db.r5.large",
+			NewInstanceClass: "db.serverless",
+			DBCluster:        provisionedCluster,
+			ExpectError:      true,
+		},
+		{
+			Name:             "serverless to provisioned in serverless v2 cluster",
+			OldInstanceClass: "db.serverless",
+			NewInstanceClass: "db.r5.large",
+			DBCluster:        serverlessV2Cluster,
+		},
+		{
+			Name:             "serverless to provisioned in provisioned cluster",
+			OldInstanceClass: "db.serverless",
+			NewInstanceClass: "db.r5.large",
+			DBCluster:        provisionedCluster,
+			ExpectError:      true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			err := validateClusterInstanceServerlessInstanceClass(tc.OldInstanceClass, tc.NewInstanceClass, tc.DBCluster)
+
+			if tc.ExpectError && err == nil {
+				t.Fatal("expected error, got none")
+			}
+
+			if !tc.ExpectError && err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+		})
+	}
+}
+
+func TestValidateClusterInstanceOrderableInstanceClass(t *testing.T) {
+	options := []*rds.OrderableDBInstanceOption{
+		{DBInstanceClass: aws.String("db.r5.large"), Engine: aws.String("aurora-postgresql"), EngineVersion: aws.String("13.7")},
+		{DBInstanceClass: aws.String("db.r6g.large"), Engine: aws.String("aurora-postgresql"), EngineVersion: aws.String("13.7")},
+		{DBInstanceClass: aws.String("db.r5.large"), Engine: aws.String("aurora-postgresql"), EngineVersion: aws.String("13.7")},
+	}
+
+	if err := validateClusterInstanceOrderableInstanceClass("db.r6g.large", "aurora-postgresql", "13.7", options); err != nil {
+		t.Fatalf("expected db.r6g.large to be valid, got: %s", err)
+	}
+
+	err := validateClusterInstanceOrderableInstanceClass("db.r6gd.large", "aurora-postgresql", "13.7", options)
+	if err == nil {
+		t.Fatal("expected db.r6gd.large to be invalid")
+	}
+	if !strings.Contains(err.Error(), `version "13.7"`) {
+		t.Fatalf("expected error to include the engine version, got: %s", err)
+	}
+	if !strings.Contains(err.Error(), "available instance classes: db.r5.large, db.r6g.large") {
+		t.Fatalf("expected error to list available instance classes once each, got: %s", err)
+	}
+
+	err = validateClusterInstanceOrderableInstanceClass("db.r6gd.large", "aurora-postgresql", "", options)
+	if err == nil {
+		t.Fatal("expected db.r6gd.large to be invalid without engine version")
+	}
+	if strings.Contains(err.Error(), "version") {
+		t.Fatalf("expected error not to include an engine version, got: %s", err)
+	}
+}
+
+func TestValidateClusterInstanceLicenseModel(t *testing.T) {
+	cases := []struct {
+		Engine       string
+		LicenseModel string
+		ErrCount     int
+	}{
+		{Engine: EngineAuroraMySQL, LicenseModel: "general-public-license", ErrCount: 0},
+		{Engine: EngineAuroraMySQL, LicenseModel: "license-included", ErrCount: 1},
+		{Engine: EngineAuroraPostgreSQL, LicenseModel: "postgresql-license", ErrCount: 0},
+		{Engine: EngineAuroraPostgreSQL, LicenseModel: "general-public-license", ErrCount: 1},
+		{Engine: EngineMySQL, LicenseModel: "general-public-license", ErrCount: 0},
+		{Engine: EnginePostgres, LicenseModel: "license-included", ErrCount: 1},
+		{Engine: "unknown-engine", LicenseModel: "license-included", ErrCount: 0},
+	}
+
+	for _, tc := range cases {
+		err := validateClusterInstanceLicenseModel(tc.Engine, tc.LicenseModel)
+
+		if tc.ErrCount == 0 && err != nil {
+			t.Errorf("%s/%s: unexpected error: %s", tc.Engine, tc.LicenseModel, err)
+		}
+
+		if tc.ErrCount != 0 && err == nil {
+			t.Errorf("%s/%s: expected error", tc.Engine, tc.LicenseModel)
+		}
+	}
+}
+
+func TestValidateClusterInstanceApplyImmediatelyOverrides(t *testing.T) {
+	cases := []struct {
+		Value    map[string]interface{}
+		ErrCount int
+	}{
+		{
+			Value:    map[string]interface{}{},
+			ErrCount: 0,
+		},
+		{
+			Value:    map[string]interface{}{"instance_class": true, "preferred_maintenance_window": false},
+			ErrCount: 0,
+		},
+		{
+			Value:    map[string]interface{}{"instance_class": true, "engine": true, "tags": false},
+			ErrCount: 2,
+		},
+	}
+
+	for _, tc := range cases {
+		_, errors := validateClusterInstanceApplyImmediatelyOverrides(tc.Value, "apply_immediately_overrides")
+
+		if len(errors) != tc.ErrCount {
+			t.Errorf("%v: got %d errors, expected %d: %v", tc.Value, len(errors), tc.ErrCount, errors)
+		}
+	}
+}
+
+func TestClusterInstanceApplyImmediately(t *testing.T) {
+	cases := map[string]struct {
+		ApplyImmediately bool
+		Overrides        map[string]interface{}
+		Keys             []string
+		Expected         bool
+	}{
+		"no overrides deferred": {
+			ApplyImmediately: false,
+			Keys:             []string{"instance_class"},
+			Expected:         false,
+		},
+		"no overrides immediate": {
+			ApplyImmediately: true,
+			Keys:             []string{"instance_class"},
+			Expected:         true,
+		},
+		"override immediate": {
+			ApplyImmediately: false,
+			Overrides:        map[string]interface{}{"instance_class": true},
+			Keys:             []string{"instance_class"},
+			Expected:         true,
+		},
+		"override deferred": {
+			ApplyImmediately: true,
+			Overrides:        map[string]interface{}{"preferred_maintenance_window": false},
+			Keys:             []string{"preferred_maintenance_window"},
+			Expected:         false,
+		},
+		"override of other attribute": {
+			ApplyImmediately: true,
+			Overrides:        map[string]interface{}{"preferred_maintenance_window": false},
+			Keys:             []string{"instance_class"},
+			Expected:         true,
+		},
+		"together all deferred": {
+			ApplyImmediately: true,
+			Overrides:        map[string]interface{}{"monitoring_interval": false, "monitoring_role_arn": false},
+			Keys:             []string{"monitoring_interval", "monitoring_role_arn"},
+			Expected:         false,
+		},
+		"together one immediate override": {
+			ApplyImmediately: false,
+			Overrides:        map[string]interface{}{"monitoring_interval": false, "monitoring_role_arn": true},
+			Keys:             []string{"monitoring_interval", "monitoring_role_arn"},
+			Expected:         true,
+		},
+		"together one immediate fallback": {
+			ApplyImmediately: true,
+			Overrides:        map[string]interface{}{"monitoring_interval": false},
+			Keys:             []string{"monitoring_interval", "monitoring_role_arn"},
+			Expected:         true,
+		},
+		"no keys": {
+			ApplyImmediately: true,
+			Overrides:        map[string]interface{}{"instance_class": false},
+			Expected:         true,
+		},
+	}
+
+	for name, tc := range cases {
+		if got := clusterInstanceApplyImmediately(tc.ApplyImmediately, tc.Overrides, tc.Keys...); got != tc.Expected {
+			t.Errorf("%s: got %t, expected %t", name, got, tc.Expected)
+		}
+	}
+}
+
+func TestValidateClusterInstancePerformanceInsightsRetentionPeriod(t *testing.T) {
+	cases := []struct {
+		PerformanceInsightsEnabled bool
+		RetentionPeriod            int
+		ErrCount                   int
+	}{
+		// Performance Insights stays disabled and retention isn't set.
+		{PerformanceInsightsEnabled: false, RetentionPeriod: 0, ErrCount: 0},
+		// Retention is set in the same update that enables Performance Insights.
+		{PerformanceInsightsEnabled: true, RetentionPeriod: 731, ErrCount: 0},
+		// Retention is set while Performance Insights stays disabled.
+		{PerformanceInsightsEnabled: false, RetentionPeriod: 7, ErrCount: 1},
+		{PerformanceInsightsEnabled: false, RetentionPeriod: 731, ErrCount: 1},
+	}
+
+	for _, tc := range cases {
+		err := validateClusterInstancePerformanceInsightsRetentionPeriod(tc.PerformanceInsightsEnabled, tc.RetentionPeriod)
+
+		if tc.ErrCount == 0 && err != nil {
+			t.Errorf("%t/%d: unexpected error: %s", tc.PerformanceInsightsEnabled, tc.RetentionPeriod, err)
+		}
+
+		if tc.ErrCount != 0 && err == nil {
+			t.Errorf("%t/%d: expected error", tc.PerformanceInsightsEnabled, tc.RetentionPeriod)
+		}
+	}
+}
+
+func TestValidateClusterInstancePubliclyAccessible(t *testing.T) {
+	cases := map[string]struct {
+		AllowPublicAccess *bool
+		WarningCount      int
+		ErrCount          int
+	}{
+		"allow_public_access not set": {
+			AllowPublicAccess: nil,
+			WarningCount:      1,
+			ErrCount:          0,
+		},
+		"allow_public_access false": {
+			AllowPublicAccess: aws.Bool(false),
+			WarningCount:      0,
+			ErrCount:          1,
+		},
+		"allow_public_access true": {
+			AllowPublicAccess: aws.Bool(true),
+			WarningCount:      0,
+			ErrCount:          0,
+		},
+	}
+
+	for name, tc := range cases {
+		warnings, err := validateClusterInstancePubliclyAccessible(tc.AllowPublicAccess)
+
+		if len(warnings) != tc.WarningCount {
+			t.Errorf("%s: got %d warnings, expected %d: %v", name, len(warnings), tc.WarningCount, warnings)
+		}
+
+		if tc.ErrCount == 0 && err != nil {
+			t.Errorf("%s: unexpected error: %s", name, err)
+		}
+
+		if tc.ErrCount != 0 && err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestValidateClusterInstanceMonitoringInterval(t *testing.T) {
+	cases := map[string]struct {
+		OldInterval      int
+		NewInterval      int
+		ApplyImmediately bool
+		WarningCount     int
+	}{
+		"enabled, deferred": {
+			OldInterval:  0,
+			NewInterval:  60,
+			WarningCount: 1,
+		},
+		"changed, deferred": {
+			OldInterval:  60,
+			NewInterval:  5,
+			WarningCount: 1,
+		},
+		"changed, applied immediately": {
+			OldInterval:      60,
+			NewInterval:      5,
+			ApplyImmediately: true,
+			WarningCount:     0,
+		},
+		"unchanged, deferred": {
+			OldInterval:  60,
+			NewInterval:  60,
+			WarningCount: 0,
+		},
+	}
+
+	for name, tc := range cases {
+		if warnings := validateClusterInstanceMonitoringInterval(tc.OldInterval, tc.NewInterval, tc.ApplyImmediately); len(warnings) != tc.WarningCount {
+			t.Errorf("%s: got %d warnings, expected %d: %v", name, len(warnings), tc.WarningCount, warnings)
+		}
+	}
+}
+
+func TestValidateClusterInstanceChangeFreeze(t *testing.T) {
+	changeFreeze := []interface{}{
+		map[string]interface{}{
+			"start": "2022-12-19T00:00:00Z",
+			"end":   "2023-01-02T00:00:00Z",
+		},
+	}
+
+	cases := map[string]struct {
+		ChangeFreeze []interface{}
+		Now          string
+		ErrCount     int
+	}{
+		"no change freeze": {
+			Now:      "2022-12-25T12:00:00Z",
+			ErrCount: 0,
+		},
+		"before window": {
+			ChangeFreeze: changeFreeze,
+			Now:          "2022-12-18T23:59:59Z",
+			ErrCount:     0,
+		},
+		"window start": {
+			ChangeFreeze: changeFreeze,
+			Now:          "2022-12-19T00:00:00Z",
+			ErrCount:     1,
+		},
+		"in window": {
+			ChangeFreeze: changeFreeze,
+			Now:          "2022-12-25T12:00:00+01:00",
+			ErrCount:     1,
+		},
+		"window end": {
+			ChangeFreeze: changeFreeze,
+			Now:          "2023-01-02T00:00:00Z",
+			ErrCount:     0,
+		},
+		"after window": {
+			ChangeFreeze: changeFreeze,
+			Now:          "2023-01-03T00:00:00Z",
+			ErrCount:     0,
+		},
+	}
+
+	for name, tc := range cases {
+		now, err := time.Parse(time.RFC3339, tc.Now)
+
+		if err != nil {
+			t.Fatalf("%s: %s", name, err)
+		}
+
+		err = validateClusterInstanceChangeFreeze(tc.ChangeFreeze, now)
+
+		if tc.ErrCount == 0 && err != nil {
+			t.Errorf("%s: unexpected error: %s", name, err)
+		}
+
+		if tc.ErrCount != 0 && err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestClusterInstanceUpdateAppliesImmediately(t *testing.T) {
+	cases := map[string]struct {
+		RequestImmediate              bool
+		EnableAutoMinorVersionUpgrade bool
+		RebootTriggerChanged          bool
+		MaintenanceActionOptInType    string
+		Expected                      bool
+	}{
+		"deferred modifications only": {
+			Expected: false,
+		},
+		"immediate modifications": {
+			RequestImmediate: true,
+			Expected:         true,
+		},
+		"auto minor version upgrade after engine version": {
+			EnableAutoMinorVersionUpgrade: true,
+			Expected:                      true,
+		},
+		"reboot": {
+			RebootTriggerChanged: true,
+			Expected:             true,
+		},
+		"maintenance actions immediately": {
+			MaintenanceActionOptInType: OptInTypeImmediate,
+			Expected:                   true,
+		},
+		"maintenance actions next maintenance window": {
+			MaintenanceActionOptInType: OptInTypeNextMaintenance,
+			Expected:                   false,
+		},
+		"maintenance actions undo opt in": {
+			MaintenanceActionOptInType: OptInTypeUndoOptIn,
+			Expected:                   false,
+		},
+	}
+
+	for name, tc := range cases {
+		if got := clusterInstanceUpdateAppliesImmediately(tc.RequestImmediate, tc.EnableAutoMinorVersionUpgrade, tc.RebootTriggerChanged, tc.MaintenanceActionOptInType); got != tc.Expected {
+			t.Errorf("%s: got %t, expected %t", name, got, tc.Expected)
+		}
+	}
+}
+
+func TestValidateClusterInstanceClusterEngineMode(t *testing.T) {
+	cases := []struct {
+		EngineMode string
+		ErrCount   int
+	}{
+		{EngineMode: EngineModeServerless, ErrCount: 1},
+		{EngineMode: EngineModeProvisioned, ErrCount: 0},
+		{EngineMode: EngineModeGlobal, ErrCount: 0},
+		{EngineMode: EngineModeParallelQuery, ErrCount: 0},
+		{EngineMode: EngineModeMultiMaster, ErrCount: 0},
+		{EngineMode: "", ErrCount: 0},
+	}
+
+	for _, tc := range cases {
+		dbCluster := &rds.DBCluster{
+			DBClusterIdentifier: aws.String("tf-test"),
+			EngineMode:          aws.String(tc.EngineMode),
+		}
+
+		err := validateClusterInstanceClusterEngineMode(dbCluster)
+
+		if tc.ErrCount == 0 && err != nil {
+			t.Errorf("%q: unexpected error: %s", tc.EngineMode, err)
+		}
+
+		if tc.ErrCount != 0 && err == nil {
+			t.Errorf("%q: expected error", tc.EngineMode)
+		}
+	}
+}
+
+func TestValidateClusterInstanceAvailabilityZone(t *testing.T) {
+	dbSubnetGroup := &rds.DBSubnetGroup{
+		DBSubnetGroupName: aws.String("tf-test"),
+		Subnets: []*rds.Subnet{
+			{SubnetAvailabilityZone: &rds.AvailabilityZone{Name: aws.String("us-west-2b")}},
+			nil,
+			{SubnetAvailabilityZone: &rds.AvailabilityZone{Name: aws.String("us-west-2a")}},
+		},
+	}
+
+	cases := []struct {
+		AvailabilityZone string
+		ErrCount         int
+	}{
+		{AvailabilityZone: "us-west-2a", ErrCount: 0},
+		{AvailabilityZone: "us-west-2b", ErrCount: 0},
+		{AvailabilityZone: "us-west-2c", ErrCount: 1},
+	}
+
+	for _, tc := range cases {
+		err := validateClusterInstanceAvailabilityZone(tc.AvailabilityZone, dbSubnetGroup)
+
+		if tc.ErrCount == 0 && err != nil {
+			t.Errorf("%s: unexpected error: %s", tc.AvailabilityZone, err)
+		}
+
+		if tc.ErrCount != 0 && err == nil {
+			t.Errorf("%s: expected error", tc.AvailabilityZone)
+		}
+
+		if tc.ErrCount != 0 && err != nil && !strings.Contains(err.Error(), "us-west-2a, us-west-2b") {
+			t.Errorf("%s: expected the error to list the covered Availability Zones, got: %s", tc.AvailabilityZone, err)
+		}
+	}
+}
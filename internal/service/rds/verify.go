@@ -1,6 +1,11 @@
 package rds
 
 import (
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/rds"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -17,3 +22,90 @@ func compareActualEngineVersion(d *schema.ResourceData, oldVersion string, newVe
 
 	d.Set("engine_version_actual", newVersion)
 }
+
+// engineVersionSatisfies returns whether the actual engine version is the configured engine version
+// or a patch-level version of it, e.g. "8.0.27" for "8.0".
+func engineVersionSatisfies(configuredVersion, actualVersion string) bool {
+	return actualVersion == configuredVersion || strings.HasPrefix(actualVersion, configuredVersion+".")
+}
+
+// dbClusterEngineVersionApplying returns whether the DB cluster runs the specified engine version, or is being upgraded to it.
+// An engine version upgrade that is deferred to the maintenance window isn't being applied.
+func dbClusterEngineVersionApplying(dbCluster *rds.DBCluster, engineVersion string) bool {
+	if engineVersionSatisfies(engineVersion, aws.StringValue(dbCluster.EngineVersion)) {
+		return true
+	}
+
+	if aws.StringValue(dbCluster.Status) != ClusterStatusUpgrading {
+		return false
+	}
+
+	// The target of an upgrade in progress may not be reported.
+	if v := dbCluster.PendingModifiedValues; v != nil && v.EngineVersion != nil {
+		return engineVersionSatisfies(engineVersion, aws.StringValue(v.EngineVersion))
+	}
+
+	return true
+}
+
+// engineVersionMajor returns the major version of an engine version, e.g. "15" for PostgreSQL 15.4,
+// "9.6" for PostgreSQL 9.6.22 and "8.0" for MySQL 8.0.mysql_aurora.3.02.0.
+// PostgreSQL 10 and later use a single component major version, MySQL uses two.
+func engineVersionMajor(engine, version string) string {
+	parts := strings.Split(version, ".")
+
+	if engine == EngineAuroraPostgreSQL || engine == EnginePostgres {
+		if v, err := strconv.Atoi(parts[0]); err == nil && v >= 10 {
+			return parts[0]
+		}
+	}
+
+	if len(parts) < 2 {
+		return version
+	}
+
+	return parts[0] + "." + parts[1]
+}
+
+// compareEngineVersions compares two engine versions component by component, numerically where both components are numbers.
+// It returns a negative number if a is older than b, a positive number if a is newer than b and 0 if they are equal.
+func compareEngineVersions(a, b string) int {
+	aParts, bParts := strings.Split(a, "."), strings.Split(b, ".")
+
+	for i := 0; i < len(aParts) && i < len(bParts); i++ {
+		aNum, aErr := strconv.Atoi(aParts[i])
+		bNum, bErr := strconv.Atoi(bParts[i])
+
+		if aErr == nil && bErr == nil {
+			if aNum != bNum {
+				return aNum - bNum
+			}
+
+			continue
+		}
+
+		if c := strings.Compare(aParts[i], bParts[i]); c != 0 {
+			return c
+		}
+	}
+
+	return len(aParts) - len(bParts)
+}
+
+// latestEngineVersion returns the newest available engine version, or "" if there is none.
+func latestEngineVersion(engineVersions []*rds.DBEngineVersion) string {
+	var latest string
+
+	for _, engineVersion := range engineVersions {
+		// Status is only reported for some engines, in which case deprecated versions can't be used for new instances.
+		if v := aws.StringValue(engineVersion.Status); v != "" && v != "available" {
+			continue
+		}
+
+		if v := aws.StringValue(engineVersion.EngineVersion); latest == "" || compareEngineVersions(v, latest) > 0 {
+			latest = v
+		}
+	}
+
+	return latest
+}
@@ -4,11 +4,16 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net"
+	"os"
 	"strconv"
 	"time"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/rds"
+	"github.com/aws/aws-sdk-go/service/rds/rdsiface"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-provider-aws/internal/tfresource"
 )
 
 const (
@@ -17,6 +22,8 @@ const (
 
 	dbClusterActivityStreamStartedTimeout = 30 * time.Minute
 	dbClusterActivityStreamStoppedTimeout = 30 * time.Minute
+
+	clusterInstanceDialTimeout = 5 * time.Second
 )
 
 func waitEventSubscriptionCreated(conn *rds.RDS, id string, timeout time.Duration) (*rds.EventSubscription, error) {
@@ -184,18 +191,34 @@ func waitDBInstanceDeleted(conn *rds.RDS, id string, timeout time.Duration) (*rd
 	return nil, err
 }
 
+// clusterInstancePollDelays returns the delay before the first poll and the minimum time between polls
+// when waiting for a cluster instance to be created, updated or deleted.
+func clusterInstancePollDelays() (delay, minTimeout time.Duration) {
+	if os.Getenv(clusterInstanceFastPollEnvVar) != "" {
+		return clusterInstanceFastPollDelay, clusterInstanceFastPollMinTimeout
+	}
+
+	return clusterInstancePollDelay, clusterInstancePollMinTimeout
+}
+
 func waitDBClusterInstanceDeleted(conn *rds.RDS, id string, timeout time.Duration) (*rds.DBInstance, error) {
+	delay, minTimeout := clusterInstancePollDelays()
 	stateConf := &resource.StateChangeConf{
 		Pending: []string{
 			InstanceStatusConfiguringLogExports,
 			InstanceStatusDeleting,
 			InstanceStatusModifying,
 		},
-		Target:     []string{},
-		Refresh:    statusDBInstance(conn, id),
+		Target: []string{},
+		// Instances in these states never finish deleting.
+		Refresh: statusDBInstanceFailOnStates(statusDBInstance(conn, id),
+			InstanceStatusFailed,
+			InstanceStatusInaccessibleEncryptionCredentials,
+			InstanceStatusIncompatibleNetwork,
+		),
 		Timeout:    timeout,
-		MinTimeout: 10 * time.Second,
-		Delay:      30 * time.Second,
+		MinTimeout: minTimeout,
+		Delay:      delay,
 	}
 
 	outputRaw, err := stateConf.WaitForState()
@@ -207,6 +230,37 @@ func waitDBClusterInstanceDeleted(conn *rds.RDS, id string, timeout time.Duratio
 	return nil, err
 }
 
+// waitDBInstanceDeletingDeleted waits for a DB instance that is being deleted, e.g. one left behind by a failed create,
+// to be deleted so that its identifier can be reused. It returns immediately if no such DB instance is being deleted.
+func waitDBInstanceDeletingDeleted(conn rdsiface.RDSAPI, id string, timeout time.Duration) error {
+	dbInstance, err := FindDBInstanceByID(conn, id)
+
+	if tfresource.NotFound(err) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if aws.StringValue(dbInstance.DBInstanceStatus) != InstanceStatusDeleting {
+		return nil
+	}
+
+	log.Printf("[DEBUG] Waiting for RDS DB Instance (%s) being deleted to be deleted", id)
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{InstanceStatusDeleting},
+		Target:     []string{},
+		Refresh:    statusDBInstance(conn, id),
+		Timeout:    timeout,
+		MinTimeout: 10 * time.Second,
+	}
+
+	_, err = stateConf.WaitForState()
+
+	return err
+}
+
 // waitActivityStreamStarted waits for Aurora Cluster Activity Stream to be started
 func waitActivityStreamStarted(ctx context.Context, conn *rds.RDS, dbClusterArn string) error {
 	log.Printf("[DEBUG] Waiting for RDS Cluster Activity Stream %s to become started...", dbClusterArn)
@@ -283,6 +337,174 @@ func waitDBInstanceAutomatedBackupDeleted(conn *rds.RDS, dbInstanceID, dbInstanc
 	return nil, err
 }
 
+// waitDBInstanceAvailable waits for a database instance to finish any in-flight operation, e.g. a modification.
+// Modifications that are pending for the maintenance window don't keep it from being available.
+func waitDBInstanceAvailable(conn rdsiface.RDSAPI, id string, timeout time.Duration) (*rds.DBInstance, error) {
+	_, minTimeout := clusterInstancePollDelays()
+	stateConf := &resource.StateChangeConf{
+		Pending:    resourceClusterInstanceCreateUpdatePendingStates,
+		Target:     []string{InstanceStatusAvailable},
+		Refresh:    statusDBInstance(conn, id),
+		Timeout:    timeout,
+		MinTimeout: minTimeout,
+	}
+
+	outputRaw, err := stateConf.WaitForState()
+
+	if output, ok := outputRaw.(*rds.DBInstance); ok {
+		return output, err
+	}
+
+	return nil, err
+}
+
+// waitDBInstanceEndpointAvailable waits for a database instance to report its endpoint.
+func waitDBInstanceEndpointAvailable(conn *rds.RDS, id string, timeout time.Duration) (*rds.DBInstance, error) {
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{strconv.FormatBool(false)},
+		Target:     []string{strconv.FormatBool(true)},
+		Refresh:    statusDBInstanceHasEndpoint(conn, id),
+		Timeout:    timeout,
+		MinTimeout: 5 * time.Second,
+	}
+
+	outputRaw, err := stateConf.WaitForState()
+
+	if output, ok := outputRaw.(*rds.DBInstance); ok {
+		return output, err
+	}
+
+	return nil, err
+}
+
+// waitDBInstanceEngineVersionSatisfied waits for a database instance to run the specified engine version.
+func waitDBInstanceEngineVersionSatisfied(conn *rds.RDS, id, engineVersion string, timeout time.Duration) (*rds.DBInstance, error) {
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{strconv.FormatBool(false)},
+		Target:     []string{strconv.FormatBool(true)},
+		Refresh:    statusDBInstanceEngineVersionSatisfied(conn, id, engineVersion),
+		Timeout:    timeout,
+		MinTimeout: 10 * time.Second,
+	}
+
+	outputRaw, err := stateConf.WaitForState()
+
+	if output, ok := outputRaw.(*rds.DBInstance); ok {
+		return output, err
+	}
+
+	return nil, err
+}
+
+// waitDBInstanceCACertificateIdentifierApplied waits for a DB instance to report the specified CA certificate.
+// RDS can report the DB instance as available before a CA certificate rotation is reflected.
+func waitDBInstanceCACertificateIdentifierApplied(conn rdsiface.RDSAPI, id, caCertificateIdentifier string, timeout time.Duration) (*rds.DBInstance, error) {
+	_, minTimeout := clusterInstancePollDelays()
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{strconv.FormatBool(false)},
+		Target:     []string{strconv.FormatBool(true)},
+		Refresh:    statusDBInstanceCACertificateIdentifierApplied(conn, id, caCertificateIdentifier),
+		Timeout:    timeout,
+		MinTimeout: minTimeout,
+	}
+
+	outputRaw, err := stateConf.WaitForState()
+
+	if output, ok := outputRaw.(*rds.DBInstance); ok {
+		return output, err
+	}
+
+	return nil, err
+}
+
+// waitDBInstancePromotionTierApplied waits for a DB instance to report the specified promotion tier.
+// Some engine versions report a changed promotion tier with a delay.
+func waitDBInstancePromotionTierApplied(conn rdsiface.RDSAPI, id string, promotionTier int64, timeout time.Duration) (*rds.DBInstance, error) {
+	_, minTimeout := clusterInstancePollDelays()
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{strconv.FormatBool(false)},
+		Target:     []string{strconv.FormatBool(true)},
+		Refresh:    statusDBInstancePromotionTierApplied(conn, id, promotionTier),
+		Timeout:    timeout,
+		MinTimeout: minTimeout,
+	}
+
+	outputRaw, err := stateConf.WaitForState()
+
+	if output, ok := outputRaw.(*rds.DBInstance); ok {
+		return output, err
+	}
+
+	return nil, err
+}
+
+// dialFunc is the signature of net.DialTimeout.
+type dialFunc func(network, address string, timeout time.Duration) (net.Conn, error)
+
+// waitClusterInstanceAcceptingConnections waits for a TCP connection to the specified address to succeed.
+// No data is sent, the connection is closed as soon as it is established.
+func waitClusterInstanceAcceptingConnections(dial dialFunc, address string, timeout time.Duration) error {
+	var lastErr error
+
+	err := resource.Retry(timeout, func() *resource.RetryError {
+		conn, err := dial("tcp", address, clusterInstanceDialTimeout)
+
+		if err != nil {
+			lastErr = err
+			return resource.RetryableError(err)
+		}
+
+		conn.Close()
+
+		return nil
+	})
+
+	if tfresource.TimedOut(err) && lastErr != nil {
+		return fmt.Errorf("%w: %s", err, lastErr)
+	}
+
+	return err
+}
+
+// waitDBClusterCloudwatchLogsExportsApplied waits for a database cluster to export exactly the specified log types.
+func waitDBClusterCloudwatchLogsExportsApplied(conn rdsiface.RDSAPI, id string, logTypes []string, timeout time.Duration) (*rds.DBCluster, error) {
+	_, minTimeout := clusterInstancePollDelays()
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{strconv.FormatBool(false)},
+		Target:     []string{strconv.FormatBool(true)},
+		Refresh:    statusDBClusterCloudwatchLogsExportsApplied(conn, id, logTypes),
+		Timeout:    timeout,
+		MinTimeout: minTimeout,
+	}
+
+	outputRaw, err := stateConf.WaitForState()
+
+	if output, ok := outputRaw.(*rds.DBCluster); ok {
+		return output, err
+	}
+
+	return nil, err
+}
+
+// waitDBClusterWriterChanged waits for a database instance to no longer be the writer of its database cluster.
+func waitDBClusterWriterChanged(conn *rds.RDS, dbClusterID, dbInstanceID string, timeout time.Duration) (*rds.DBCluster, error) {
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{strconv.FormatBool(true)},
+		Target:     []string{strconv.FormatBool(false)},
+		Refresh:    statusDBClusterInstanceIsWriter(conn, dbClusterID, dbInstanceID),
+		Timeout:    timeout,
+		MinTimeout: 10 * time.Second,
+	}
+
+	outputRaw, err := stateConf.WaitForState()
+
+	if output, ok := outputRaw.(*rds.DBCluster); ok {
+		return output, err
+	}
+
+	return nil, err
+}
+
 func waitDBProxyCreated(conn *rds.RDS, name string, timeout time.Duration) (*rds.DBProxy, error) {
 	stateConf := &resource.StateChangeConf{
 		Pending: []string{rds.DBProxyStatusCreating},
@@ -0,0 +1,391 @@
+package rds
+
+import (
+	"errors"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/rds"
+	"github.com/aws/aws-sdk-go/service/rds/rdsiface"
+)
+
+func TestWaitClusterInstanceAcceptingConnections(t *testing.T) {
+	t.Run("accepting connections after retries", func(t *testing.T) {
+		var calls int
+		var gotNetwork, gotAddress string
+
+		dial := func(network, address string, timeout time.Duration) (net.Conn, error) {
+			calls++
+			gotNetwork, gotAddress = network, address
+
+			if calls < 2 {
+				return nil, errors.New("connection refused")
+			}
+
+			client, server := net.Pipe()
+			server.Close()
+
+			return client, nil
+		}
+
+		if err := waitClusterInstanceAcceptingConnections(dial, "tf-test.us-west-2.rds.amazonaws.com:3306", 1*time.Minute); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if calls != 2 {
+			t.Errorf("got %d calls, expected 2", calls)
+		}
+
+		if gotNetwork != "tcp" {
+			t.Errorf("got network %q, expected %q", gotNetwork, "tcp")
+		}
+
+		if gotAddress != "tf-test.us-west-2.rds.amazonaws.com:3306" {
+			t.Errorf("got address %q, expected %q", gotAddress, "tf-test.us-west-2.rds.amazonaws.com:3306")
+		}
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		timeout := 2 * time.Second
+		start := time.Now()
+
+		dial := func(network, address string, timeout time.Duration) (net.Conn, error) {
+			return nil, errors.New("connection refused")
+		}
+
+		err := waitClusterInstanceAcceptingConnections(dial, "tf-test.us-west-2.rds.amazonaws.com:3306", timeout)
+
+		if err == nil {
+			t.Fatal("expected error, got none")
+		}
+
+		if !strings.Contains(err.Error(), "connection refused") {
+			t.Errorf("expected error to include the last dial error, got: %s", err)
+		}
+
+		if elapsed := time.Since(start); elapsed < timeout || elapsed > timeout+10*time.Second {
+			t.Errorf("got elapsed %s, expected about %s", elapsed, timeout)
+		}
+	})
+}
+
+type mockDBInstanceStatusesConn struct {
+	rdsiface.RDSAPI
+
+	// statuses are returned by successive DescribeDBInstances calls, after which the DB instance is not found.
+	statuses              []string
+	pendingModifiedValues *rds.PendingModifiedValues
+	calls                 int
+}
+
+func (m *mockDBInstanceStatusesConn) DescribeDBInstancesPages(input *rds.DescribeDBInstancesInput, fn func(*rds.DescribeDBInstancesOutput, bool) bool) error {
+	m.calls++
+
+	if m.calls > len(m.statuses) {
+		return awserr.New(rds.ErrCodeDBInstanceNotFoundFault, "not found", nil)
+	}
+
+	fn(&rds.DescribeDBInstancesOutput{
+		DBInstances: []*rds.DBInstance{{
+			DBInstanceIdentifier:  input.DBInstanceIdentifier,
+			DBInstanceStatus:      aws.String(m.statuses[m.calls-1]),
+			PendingModifiedValues: m.pendingModifiedValues,
+		}},
+	}, true)
+
+	return nil
+}
+
+func TestWaitDBInstanceDeletingDeleted(t *testing.T) {
+	cases := map[string]struct {
+		Statuses      []string
+		ExpectedCalls int
+	}{
+		"not found": {
+			ExpectedCalls: 1,
+		},
+		"deleting then deleted": {
+			Statuses:      []string{InstanceStatusDeleting},
+			ExpectedCalls: 2,
+		},
+		"available": {
+			Statuses:      []string{InstanceStatusAvailable},
+			ExpectedCalls: 1,
+		},
+	}
+
+	for name, tc := range cases {
+		conn := &mockDBInstanceStatusesConn{statuses: tc.Statuses}
+
+		if err := waitDBInstanceDeletingDeleted(conn, "tf-test", 1*time.Minute); err != nil {
+			t.Errorf("%s: unexpected error: %s", name, err)
+		}
+
+		if conn.calls != tc.ExpectedCalls {
+			t.Errorf("%s: got %d calls, expected %d", name, conn.calls, tc.ExpectedCalls)
+		}
+	}
+}
+
+func TestClusterInstancePollDelays(t *testing.T) {
+	t.Run("default", func(t *testing.T) {
+		t.Setenv(clusterInstanceFastPollEnvVar, "")
+
+		delay, minTimeout := clusterInstancePollDelays()
+
+		if delay != 30*time.Second || minTimeout != 10*time.Second {
+			t.Errorf("got delay %s and min timeout %s, expected 30s and 10s", delay, minTimeout)
+		}
+	})
+
+	t.Run("fast poll", func(t *testing.T) {
+		t.Setenv(clusterInstanceFastPollEnvVar, "1")
+
+		delay, minTimeout := clusterInstancePollDelays()
+
+		if delay != clusterInstanceFastPollDelay || minTimeout != clusterInstanceFastPollMinTimeout {
+			t.Errorf("got delay %s and min timeout %s, expected %s and %s", delay, minTimeout, clusterInstanceFastPollDelay, clusterInstanceFastPollMinTimeout)
+		}
+	})
+}
+
+type mockDBInstanceCACertificatesConn struct {
+	rdsiface.RDSAPI
+
+	// caCertificateIdentifiers are returned by successive DescribeDBInstances calls, the last one repeatedly.
+	caCertificateIdentifiers []string
+	calls                    int
+}
+
+func (m *mockDBInstanceCACertificatesConn) DescribeDBInstancesPages(input *rds.DescribeDBInstancesInput, fn func(*rds.DescribeDBInstancesOutput, bool) bool) error {
+	m.calls++
+
+	i := m.calls - 1
+	if i >= len(m.caCertificateIdentifiers) {
+		i = len(m.caCertificateIdentifiers) - 1
+	}
+
+	fn(&rds.DescribeDBInstancesOutput{
+		DBInstances: []*rds.DBInstance{{
+			CACertificateIdentifier: aws.String(m.caCertificateIdentifiers[i]),
+			DBInstanceIdentifier:    input.DBInstanceIdentifier,
+			DBInstanceStatus:        aws.String(InstanceStatusAvailable),
+		}},
+	}, true)
+
+	return nil
+}
+
+func TestWaitDBInstanceCACertificateIdentifierApplied(t *testing.T) {
+	t.Setenv(clusterInstanceFastPollEnvVar, "1")
+
+	t.Run("applied", func(t *testing.T) {
+		conn := &mockDBInstanceCACertificatesConn{caCertificateIdentifiers: []string{"rds-ca-rsa2048-g1"}}
+
+		if _, err := waitDBInstanceCACertificateIdentifierApplied(conn, "tf-test", "rds-ca-rsa2048-g1", 1*time.Minute); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if conn.calls != 1 {
+			t.Errorf("got %d calls, expected 1", conn.calls)
+		}
+	})
+
+	t.Run("delayed", func(t *testing.T) {
+		conn := &mockDBInstanceCACertificatesConn{caCertificateIdentifiers: []string{"rds-ca-2019", "rds-ca-rsa2048-g1"}}
+
+		dbInstance, err := waitDBInstanceCACertificateIdentifierApplied(conn, "tf-test", "rds-ca-rsa2048-g1", 1*time.Minute)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if got, expected := aws.StringValue(dbInstance.CACertificateIdentifier), "rds-ca-rsa2048-g1"; got != expected {
+			t.Errorf("got CACertificateIdentifier %q, expected %q", got, expected)
+		}
+
+		if conn.calls != 2 {
+			t.Errorf("got %d calls, expected 2", conn.calls)
+		}
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		conn := &mockDBInstanceCACertificatesConn{caCertificateIdentifiers: []string{"rds-ca-2019"}}
+
+		if _, err := waitDBInstanceCACertificateIdentifierApplied(conn, "tf-test", "rds-ca-rsa2048-g1", 2*time.Second); err == nil {
+			t.Fatal("expected error, got none")
+		}
+	})
+}
+
+func TestWaitDBInstanceAvailable(t *testing.T) {
+	t.Setenv(clusterInstanceFastPollEnvVar, "1")
+
+	// Modifications deferred by an earlier apply are pending until the maintenance window.
+	deferred := &rds.PendingModifiedValues{
+		DBInstanceClass: aws.String("db.r6g.large"),
+	}
+
+	t.Run("deferred modifications pending", func(t *testing.T) {
+		conn := &mockDBInstanceStatusesConn{
+			statuses:              []string{InstanceStatusAvailable},
+			pendingModifiedValues: deferred,
+		}
+
+		if _, err := waitDBInstanceAvailable(conn, "tf-test", 1*time.Minute); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if conn.calls != 1 {
+			t.Errorf("got %d calls, expected 1", conn.calls)
+		}
+	})
+
+	t.Run("modification in flight", func(t *testing.T) {
+		conn := &mockDBInstanceStatusesConn{
+			statuses:              []string{InstanceStatusModifying, InstanceStatusAvailable},
+			pendingModifiedValues: deferred,
+		}
+
+		if _, err := waitDBInstanceAvailable(conn, "tf-test", 1*time.Minute); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if conn.calls != 2 {
+			t.Errorf("got %d calls, expected 2", conn.calls)
+		}
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		statuses := make([]string, 1000)
+		for i := range statuses {
+			statuses[i] = InstanceStatusModifying
+		}
+		conn := &mockDBInstanceStatusesConn{statuses: statuses}
+
+		if _, err := waitDBInstanceAvailable(conn, "tf-test", 2*time.Second); err == nil {
+			t.Fatal("expected error, got none")
+		}
+	})
+}
+
+type mockDBClusterCloudwatchLogsExportsConn struct {
+	rdsiface.RDSAPI
+
+	// dbClusters are returned by successive DescribeDBClusters calls, the last one repeatedly.
+	dbClusters []*rds.DBCluster
+	calls      int
+}
+
+func (m *mockDBClusterCloudwatchLogsExportsConn) DescribeDBClusters(input *rds.DescribeDBClustersInput) (*rds.DescribeDBClustersOutput, error) {
+	m.calls++
+
+	i := m.calls - 1
+	if i >= len(m.dbClusters) {
+		i = len(m.dbClusters) - 1
+	}
+
+	dbCluster := *m.dbClusters[i]
+	dbCluster.DBClusterIdentifier = input.DBClusterIdentifier
+
+	return &rds.DescribeDBClustersOutput{DBClusters: []*rds.DBCluster{&dbCluster}}, nil
+}
+
+func TestWaitDBClusterCloudwatchLogsExportsApplied(t *testing.T) {
+	t.Setenv(clusterInstanceFastPollEnvVar, "1")
+
+	// RDS can report no pending log exports before it reports the enabled ones.
+	reportedLate := &rds.DBCluster{
+		EnabledCloudwatchLogsExports: aws.StringSlice([]string{"audit"}),
+	}
+	applied := &rds.DBCluster{
+		EnabledCloudwatchLogsExports: aws.StringSlice([]string{"audit", "error"}),
+	}
+
+	t.Run("applied", func(t *testing.T) {
+		conn := &mockDBClusterCloudwatchLogsExportsConn{dbClusters: []*rds.DBCluster{reportedLate, applied}}
+
+		dbCluster, err := waitDBClusterCloudwatchLogsExportsApplied(conn, "tf-test", []string{"error", "audit"}, 1*time.Minute)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if got := len(dbCluster.EnabledCloudwatchLogsExports); got != 2 {
+			t.Errorf("got %d enabled log exports, expected 2", got)
+		}
+
+		if conn.calls != 2 {
+			t.Errorf("got %d calls, expected 2", conn.calls)
+		}
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		conn := &mockDBClusterCloudwatchLogsExportsConn{dbClusters: []*rds.DBCluster{reportedLate}}
+
+		if _, err := waitDBClusterCloudwatchLogsExportsApplied(conn, "tf-test", []string{"audit", "error"}, 2*time.Second); err == nil {
+			t.Fatal("expected error, got none")
+		}
+	})
+}
+
+type mockDBInstancePromotionTiersConn struct {
+	rdsiface.RDSAPI
+
+	// promotionTiers are returned by successive DescribeDBInstances calls, the last one repeatedly.
+	promotionTiers []int64
+	calls          int
+}
+
+func (m *mockDBInstancePromotionTiersConn) DescribeDBInstancesPages(input *rds.DescribeDBInstancesInput, fn func(*rds.DescribeDBInstancesOutput, bool) bool) error {
+	m.calls++
+
+	i := m.calls - 1
+	if i >= len(m.promotionTiers) {
+		i = len(m.promotionTiers) - 1
+	}
+
+	fn(&rds.DescribeDBInstancesOutput{
+		DBInstances: []*rds.DBInstance{{
+			DBInstanceIdentifier: input.DBInstanceIdentifier,
+			DBInstanceStatus:     aws.String(InstanceStatusAvailable),
+			PromotionTier:        aws.Int64(m.promotionTiers[i]),
+		}},
+	}, true)
+
+	return nil
+}
+
+func TestWaitDBInstancePromotionTierApplied(t *testing.T) {
+	t.Setenv(clusterInstanceFastPollEnvVar, "1")
+
+	t.Run("delayed", func(t *testing.T) {
+		conn := &mockDBInstancePromotionTiersConn{promotionTiers: []int64{1, 3}}
+
+		dbInstance, err := waitDBInstancePromotionTierApplied(conn, "tf-test", 3, 1*time.Minute)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if got := aws.Int64Value(dbInstance.PromotionTier); got != 3 {
+			t.Errorf("got PromotionTier %d, expected 3", got)
+		}
+
+		if conn.calls != 2 {
+			t.Errorf("got %d calls, expected 2", conn.calls)
+		}
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		conn := &mockDBInstancePromotionTiersConn{promotionTiers: []int64{1}}
+
+		if _, err := waitDBInstancePromotionTierApplied(conn, "tf-test", 3, 2*time.Second); err == nil {
+			t.Fatal("expected error, got none")
+		}
+	})
+}
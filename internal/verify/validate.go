@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -155,6 +156,35 @@ func ValidateIPv6CIDRBlock(cidr string) error {
 	return nil
 }
 
+// IdentifierPrefixMaxLength is the maximum length of a prefix from which resource.PrefixedUniqueId
+// generates an identifier of at most 63 characters, e.g. a DB instance identifier.
+const IdentifierPrefixMaxLength = 63 - resource.UniqueIDSuffixLength
+
+// ValidateIdentifierPrefix validates that the specified identifier prefix is valid:
+// - The prefix only contains lowercase alphanumeric characters and hyphens
+// - The prefix starts with a letter
+// - The prefix doesn't contain two consecutive hyphens
+// - The prefix is at most IdentifierPrefixMaxLength characters long
+func ValidateIdentifierPrefix(prefix string) error {
+	if !regexp.MustCompile(`^[0-9a-z-]+$`).MatchString(prefix) {
+		return fmt.Errorf("%q must only contain lowercase alphanumeric characters and hyphens", prefix)
+	}
+
+	if !regexp.MustCompile(`^[a-z]`).MatchString(prefix) {
+		return fmt.Errorf("%q must start with a letter", prefix)
+	}
+
+	if strings.Contains(prefix, "--") {
+		return fmt.Errorf("%q cannot contain two consecutive hyphens", prefix)
+	}
+
+	if len(prefix) > IdentifierPrefixMaxLength {
+		return fmt.Errorf("%q cannot be longer than %d characters", prefix, IdentifierPrefixMaxLength)
+	}
+
+	return nil
+}
+
 // ValidIPv4CIDRNetworkAddress ensures that the string value is a valid IPv4 CIDR that
 // represents a network address - it adds an error otherwise
 func ValidIPv4CIDRNetworkAddress(v interface{}, k string) (ws []string, errors []error) {
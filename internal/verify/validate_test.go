@@ -266,6 +266,33 @@ func TestValidIPv4CIDRBlock(t *testing.T) {
 	}
 }
 
+func TestValidateIdentifierPrefix(t *testing.T) {
+	for _, ts := range []struct {
+		prefix string
+		valid  bool
+	}{
+		{"tf-", true},
+		{"team-a-", true},
+		{"a1", true},
+		{strings.Repeat("a", IdentifierPrefixMaxLength), true},
+		{strings.Repeat("a", IdentifierPrefixMaxLength+1), false},
+		{"", false},
+		{"Team-", false},
+		{"team_a-", false},
+		{"1team-", false},
+		{"-team", false},
+		{"team--a", false},
+	} {
+		err := ValidateIdentifierPrefix(ts.prefix)
+		if !ts.valid && err == nil {
+			t.Fatalf("Input '%s' should error but didn't!", ts.prefix)
+		}
+		if ts.valid && err != nil {
+			t.Fatalf("Got unexpected error for '%s' input: %s", ts.prefix, err)
+		}
+	}
+}
+
 func TestValidIPv6CIDRBlock(t *testing.T) {
 	for _, ts := range []struct {
 		cidr  string